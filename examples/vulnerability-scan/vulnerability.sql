@@ -0,0 +1,59 @@
+-- A minimal grype (schema v5) vulnerability database, load it with: sqlite3 vulnerability.db < vulnerability.sql
+CREATE TABLE id (build_timestamp DATETIME, schema_version INTEGER);
+CREATE TABLE vulnerability (
+  pk INTEGER PRIMARY KEY AUTOINCREMENT,
+  id TEXT,
+  package_name TEXT,
+  namespace TEXT,
+  version_constraint TEXT,
+  version_format TEXT,
+  cpes TEXT,
+  related_vulnerabilities TEXT,
+  fixed_in_versions TEXT,
+  fix_state TEXT,
+  advisories TEXT
+);
+CREATE TABLE vulnerability_metadata (
+  id TEXT,
+  namespace TEXT,
+  data_source TEXT,
+  record_source TEXT,
+  severity TEXT,
+  urls TEXT,
+  description TEXT,
+  cvss TEXT,
+  PRIMARY KEY (id, namespace)
+);
+
+INSERT INTO id VALUES ('2023-01-01 00:00:00', 5);
+
+-- Debian packages (dpkg versions): the epoch is compared first, '~' sorts before the release and letters after it are newer
+INSERT INTO vulnerability (id, package_name, namespace, version_constraint, version_format, fixed_in_versions, fix_state) VALUES
+  ('CVE-0000-0001', 'epoch-demo', 'debian:distro:debian:11', '< 1:1.5', 'dpkg', '["1:1.5"]', 'fixed'),
+  ('CVE-0000-0002', 'epoch-demo', 'debian:distro:debian:11', '< 2.0', 'dpkg', '["2.0"]', 'fixed'),
+  ('CVE-0000-0003', 'tilde-demo', 'debian:distro:debian:11', '< 2.0-1', 'dpkg', '["2.0-1"]', 'fixed'),
+  ('CVE-0000-0004', 'tilde-demo', 'debian:distro:debian:11', '< 2.0~beta1', 'dpkg', '["2.0~beta1"]', 'fixed'),
+  ('CVE-0000-0005', 'suffix-demo', 'debian:distro:debian:11', '< 1.0', 'dpkg', '["1.0"]', 'fixed');
+
+-- npm packages: pre-releases are older than their release and || separates ranges
+INSERT INTO vulnerability (id, package_name, namespace, version_constraint, version_format, fixed_in_versions, fix_state) VALUES
+  ('GHSA-0000-0001', 'prerelease-demo', 'github:language:javascript', '< 1.2.0', 'unknown', '["1.2.0"]', 'fixed'),
+  ('GHSA-0000-0002', 'prerelease-demo', 'github:language:javascript', '>= 1.2.0, < 1.3.0', 'unknown', '["1.3.0"]', 'fixed'),
+  ('GHSA-0000-0003', 'ranges-demo', 'github:language:javascript', '>= 1.0.0, < 1.0.5 || >= 2.0.0, < 2.0.3', 'unknown', '["1.0.5","2.0.3"]', 'fixed'),
+  ('GHSA-0000-0004', 'ranges-demo', 'github:language:javascript', '< 1.0.5 || >= 3.0.0', 'unknown', '["1.0.5"]', 'fixed');
+
+-- python packages: names are recorded normalized (lowercase with '-' for runs of '-', '_' and '.')
+INSERT INTO vulnerability (id, package_name, namespace, version_constraint, version_format, fixed_in_versions, fix_state) VALUES
+  ('GHSA-0000-0005', 'mixed-case-demo', 'github:language:python', '< 1.1.0', 'python', '["1.1.0"]', 'fixed');
+
+INSERT INTO vulnerability_metadata (id, namespace, severity) VALUES
+  ('CVE-0000-0001', 'debian:distro:debian:11', 'High'),
+  ('CVE-0000-0002', 'debian:distro:debian:11', 'High'),
+  ('CVE-0000-0003', 'debian:distro:debian:11', 'Medium'),
+  ('CVE-0000-0004', 'debian:distro:debian:11', 'Medium'),
+  ('CVE-0000-0005', 'debian:distro:debian:11', 'Low'),
+  ('GHSA-0000-0001', 'github:language:javascript', 'Critical'),
+  ('GHSA-0000-0002', 'github:language:javascript', 'Critical'),
+  ('GHSA-0000-0003', 'github:language:javascript', 'Low'),
+  ('GHSA-0000-0004', 'github:language:javascript', 'Low'),
+  ('GHSA-0000-0005', 'github:language:python', 'Medium');
@@ -14,6 +14,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/packager/git"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/packager"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
@@ -35,6 +36,9 @@ var initCmd = &cobra.Command{
 		if err := validateInitFlags(); err != nil {
 			message.Fatal(err, lang.CmdInitErrFlags)
 		}
+		if err := validateConfigSetValues(cmd); err != nil {
+			message.Fatal(err, err.Error())
+		}
 
 		// Continue running package deploy for all components like any other package
 		initPackageName := packager.GetInitPackageName("")
@@ -122,7 +126,9 @@ func downloadInitPackage(initPackageName string) error {
 
 	// If the user wants to download the init-package, download it
 	if confirmDownload {
-		utils.DownloadToFile(url, pkgConfig.DeployOpts.PackagePath, "")
+		if err := utils.DownloadToFile(url, pkgConfig.DeployOpts.PackagePath, ""); err != nil {
+			return err
+		}
 	} else {
 		// Otherwise, exit and tell the user to manually download the init-package
 		return errors.New(lang.CmdInitDownloadErrManual)
@@ -139,6 +145,17 @@ func validateInitFlags() error {
 		}
 	}
 
+	// The internal git server is always Gitea, other providers only apply to an external git server
+	if err := git.ValidateProvider(pkgConfig.InitOpts.GitServer.Provider); err != nil {
+		return err
+	}
+	if err := git.ValidateRepoNaming(pkgConfig.InitOpts.GitServer.RepoNaming); err != nil {
+		return err
+	}
+	if pkgConfig.InitOpts.GitServer.Address == "" && pkgConfig.InitOpts.GitServer.Provider != "" && pkgConfig.InitOpts.GitServer.Provider != config.ZarfGitProviderGitea {
+		return fmt.Errorf(lang.CmdInitErrValidateGitProv, pkgConfig.InitOpts.GitServer.Provider)
+	}
+
 	//If 'registry-url' is provided, make sure they provided values for the username and password of the push user
 	if pkgConfig.InitOpts.RegistryInfo.Address != "" {
 		if pkgConfig.InitOpts.RegistryInfo.PushUsername == "" || pkgConfig.InitOpts.RegistryInfo.PushPassword == "" {
@@ -164,6 +181,9 @@ func init() {
 	v.SetDefault(V_INIT_GIT_PUSH_PASS, "")
 	v.SetDefault(V_INIT_GIT_PULL_USER, "")
 	v.SetDefault(V_INIT_GIT_PULL_PASS, "")
+	v.SetDefault(V_INIT_GIT_PROVIDER, "")
+	v.SetDefault(V_INIT_GIT_GROUP, "")
+	v.SetDefault(V_INIT_GIT_NAMING, "")
 
 	v.SetDefault(V_INIT_REGISTRY_URL, "")
 	v.SetDefault(V_INIT_REGISTRY_NODEPORT, 0)
@@ -187,6 +207,9 @@ func init() {
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.PushPassword, "git-push-password", v.GetString(V_INIT_GIT_PUSH_PASS), lang.CmdInitFlagGitPushPass)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.PullUsername, "git-pull-username", v.GetString(V_INIT_GIT_PULL_USER), lang.CmdInitFlagGitPullUser)
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.PullPassword, "git-pull-password", v.GetString(V_INIT_GIT_PULL_PASS), lang.CmdInitFlagGitPullPass)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Provider, "git-provider", v.GetString(V_INIT_GIT_PROVIDER), lang.CmdInitFlagGitProvider)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Group, "git-group", v.GetString(V_INIT_GIT_GROUP), lang.CmdInitFlagGitGroup)
+	initCmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.RepoNaming, "git-repo-naming", v.GetString(V_INIT_GIT_NAMING), lang.CmdInitFlagGitNaming)
 
 	// Flags for using an external registry
 	initCmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Address, "registry-url", v.GetString(V_INIT_REGISTRY_URL), lang.CmdInitFlagRegURL)
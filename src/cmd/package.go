@@ -41,7 +41,10 @@ var packageCreateCmd = &cobra.Command{
 	Short:   "Use to create a Zarf package from a given directory or the current directory",
 	Long: "Builds an archive of resources and dependencies defined by the 'zarf.yaml' in the active directory.\n" +
 		"Private registries and repositories are accessed via credentials in your local '~/.docker/config.json' " +
-		"and '~/.git-credentials'.\n",
+		"and '~/.git-credentials' or '~/.netrc'.\n" +
+		"Git repositories can also use a per-host access token in ZARF_GIT_TOKEN_<HOST> (i.e. ZARF_GIT_TOKEN_GITLAB_EXAMPLE_COM), " +
+		"sent as the password with the username in ZARF_GIT_USERNAME_<HOST> or 'oauth2', " +
+		"and ssh:// or git@ URLs use ZARF_GIT_SSH_KEY (and ZARF_GIT_SSH_KEY_PASSPHRASE), ssh-agent or the default keys in '~/.ssh'.\n",
 	Run: func(cmd *cobra.Command, args []string) {
 
 		var baseDir string
@@ -75,6 +78,10 @@ var packageDeployCmd = &cobra.Command{
 	Long:    "Uses current kubecontext to deploy the packaged tarball onto a k8s cluster.",
 	Args:    cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateConfigSetValues(cmd); err != nil {
+			message.Fatal(err, err.Error())
+		}
+
 		pkgConfig.DeployOpts.PackagePath = choosePackage(args)
 
 		// Configure the packager
@@ -233,6 +240,9 @@ func bindCreateFlags() {
 	v.SetDefault(V_PKG_CREATE_SBOM_OUTPUT, "")
 	v.SetDefault(V_PKG_CREATE_SKIP_SBOM, false)
 	v.SetDefault(V_PKG_CREATE_INSECURE, false)
+	v.SetDefault(V_PKG_CREATE_MAX_CACHE, "")
+	v.SetDefault(V_PKG_CREATE_VULN_DB, "")
+	v.SetDefault(V_PKG_CREATE_VULN_FAIL, "")
 
 	createFlags.StringToStringVar(&pkgConfig.CreateOpts.SetVariables, "set", v.GetStringMapString(V_PKG_CREATE_SET), "Specify package variables to set on the command line (KEY=value)")
 	createFlags.StringVarP(&pkgConfig.CreateOpts.OutputDirectory, "output-directory", "o", v.GetString(V_PKG_CREATE_OUTPUT_DIR), "Specify the output directory for the created Zarf package")
@@ -240,6 +250,9 @@ func bindCreateFlags() {
 	createFlags.StringVar(&pkgConfig.CreateOpts.SBOMOutputDir, "sbom-out", v.GetString(V_PKG_CREATE_SBOM_OUTPUT), "Specify an output directory for the SBOMs from the created Zarf package")
 	createFlags.BoolVar(&pkgConfig.CreateOpts.SkipSBOM, "skip-sbom", v.GetBool(V_PKG_CREATE_SKIP_SBOM), "Skip generating SBOM for this package")
 	createFlags.BoolVar(&pkgConfig.CreateOpts.Insecure, "insecure", v.GetBool(V_PKG_CREATE_INSECURE), "Allow insecure registry connections when pulling OCI images")
+	createFlags.StringVar(&pkgConfig.CreateOpts.VulnDB, "vuln-db", v.GetString(V_PKG_CREATE_VULN_DB), "Path to an offline grype vulnerability database (vulnerability.db) to scan the package images against, the report is added to the package")
+	createFlags.StringVar(&pkgConfig.CreateOpts.VulnFailOn, "vuln-fail-on", v.GetString(V_PKG_CREATE_VULN_FAIL), "Fail package creation if a vulnerability at or above this severity is found (negligible, low, medium, high, critical)")
+	createFlags.StringVar(&pkgConfig.CreateOpts.MaxCacheSize, "max-cache-size", v.GetString(V_PKG_CREATE_MAX_CACHE), "Limit the size of the image and git repository cache, removing the least recently used entries after pulling images (e.g. 20Gi)")
 }
 
 func bindDeployFlags() {
@@ -253,8 +266,12 @@ func bindDeployFlags() {
 	v.SetDefault(V_PKG_DEPLOY_INSECURE, false)
 	v.SetDefault(V_PKG_DEPLOY_SHASUM, "")
 	v.SetDefault(V_PKG_DEPLOY_SGET, "")
+	v.SetDefault(V_PKG_DEPLOY_VALUES, []string{})
+	v.SetDefault(V_PKG_DEPLOY_RESET_VALUES, false)
 
-	deployFlags.StringToStringVar(&pkgConfig.DeployOpts.SetVariables, "set", v.GetStringMapString(V_PKG_DEPLOY_SET), "Specify deployment variables to set on the command line (KEY=value)")
+	deployFlags.StringToStringVar(&pkgConfig.DeployOpts.SetVariables, "set", v.GetStringMapString(V_PKG_DEPLOY_SET), "Specify deployment variables (KEY=value) or helm chart values (component.chart.path=value) to set on the command line")
+	deployFlags.StringArrayVar(&pkgConfig.DeployOpts.ValuesFiles, "values", v.GetStringSlice(V_PKG_DEPLOY_VALUES), "Specify helm values files to merge on top of the packaged values of a chart (component/chart=file.yaml)")
+	deployFlags.BoolVar(&pkgConfig.DeployOpts.ResetValues, "reset-values", v.GetBool(V_PKG_DEPLOY_RESET_VALUES), "Discard the helm values set by previous deployments of the package instead of keeping them")
 	deployFlags.StringVar(&pkgConfig.DeployOpts.Components, "components", v.GetString(V_PKG_DEPLOY_COMPONENTS), "Comma-separated list of components to install.  Adding this flag will skip the init prompts for which components to install")
 	deployFlags.BoolVar(&insecureDeploy, "insecure", v.GetBool(V_PKG_DEPLOY_INSECURE), "Skip shasum validation of remote package. Required if deploying a remote package and `--shasum` is not provided")
 	deployFlags.StringVar(&shasum, "shasum", v.GetString(V_PKG_DEPLOY_SHASUM), "Shasum of the package to deploy. Required if deploying a remote package and `--insecure` is not provided")
@@ -7,17 +7,22 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/internal/packager/git"
+	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/packager"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 var repoHelmChartPath string
+var lintConfirm bool
 var prepareCmd = &cobra.Command{
 	Use:     "prepare",
 	Aliases: []string{"prep"},
@@ -131,6 +136,137 @@ var prepareGenerateConfigFile = &cobra.Command{
 	},
 }
 
+var prepareLint = &cobra.Command{
+	Use:     "lint [DIRECTORY]",
+	Aliases: []string{"l"},
+	Args:    cobra.MaximumNArgs(1),
+	Short:   "Validates the zarf.yaml in a directory against the schema and the package rules",
+	Long: "Validates the zarf.yaml in a directory (the current directory by default) against the zarf.yaml schema and the package rules " +
+		"(unique component names, existing local paths, chart versions, declared variables and constants, etc).\n\n" +
+		"Every problem is reported with its file, line and YAML path and the command exits with an error if any are found.",
+	Run: func(cmd *cobra.Command, args []string) {
+		baseDir := "."
+
+		// If a directory was provided, use that as the base directory
+		if len(args) > 0 {
+			baseDir = args[0]
+		}
+
+		findings, err := validate.Lint(baseDir, lintConfirm)
+		if err != nil {
+			message.Fatalf(err, "Unable to lint the package in %s", baseDir)
+		}
+
+		// Print each finding on one line so they can be read by CI tools and editors
+		for _, finding := range findings {
+			pterm.Warning.Println(finding.String())
+		}
+
+		if len(findings) > 0 {
+			message.Fatalf(nil, "Found %d problem(s) in the package in %s", len(findings), baseDir)
+		}
+
+		message.SuccessF("The package in %s is valid", baseDir)
+	},
+}
+
+var prepareShowConfig = &cobra.Command{
+	Use:     "show-config",
+	Aliases: []string{"sc"},
+	Args:    cobra.NoArgs,
+	Short:   "Prints the effective Zarf config and where each value came from",
+	Long: "Prints the effective Zarf config after applying the config file, the selected --profile (and the profiles it extends), environment variables and flags.\n\n" +
+		"The source of each value is one of: flag, env, profile NAME, config file or default. Passwords and secrets are masked.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if v.ConfigFileUsed() != "" {
+			message.Notef("Config file: %s", v.ConfigFileUsed())
+		}
+		if len(activeProfiles) > 0 {
+			message.Notef("Profiles: %s", strings.Join(activeProfiles, " > "))
+		}
+
+		// The root flags are the only config flags this command accepts
+		rootFlags := map[string]string{
+			V_LOG_LEVEL:    "log-level",
+			V_ARCHITECTURE: "architecture",
+			V_NO_LOG_FILE:  "no-log-file",
+			V_NO_PROGRESS:  "no-progress",
+			V_ZARF_CACHE:   "zarf-cache",
+			V_TMP_DIR:      "tmpdir",
+			V_PROFILE:      "profile",
+		}
+
+		configTable := pterm.TableData{
+			{"     Key", "Value", "Source"},
+		}
+		for _, key := range configKeys() {
+			var value string
+			switch setting := v.Get(key).(type) {
+			case []string:
+				value = strings.Join(setting, ", ")
+			case map[string]any, map[string]string:
+				// Only empty maps are listed, the keys of other maps are listed instead
+			default:
+				value = fmt.Sprintf("%v", setting)
+			}
+
+			var source string
+			if flag := cmd.Flags().Lookup(rootFlags[key]); flag != nil && flag.Changed {
+				value = flag.Value.String()
+				source = "flag"
+			} else if _, ok := os.LookupEnv(configEnvName(key)); ok {
+				source = "env"
+			} else if name, ok := profileSetting(key); ok {
+				source = "profile " + name
+			} else if v.InConfig(key) {
+				source = "config file"
+			} else {
+				source = "default"
+			}
+
+			if isSensitiveConfig(key) && value != "" {
+				value = message.RedactedValue
+			}
+
+			configTable = append(configTable, pterm.TableData{{
+				fmt.Sprintf("     %s", key),
+				value,
+				source,
+			}}...)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(configTable).Render()
+	},
+}
+
+// configKeys returns the sorted config keys, without the profile definitions or keys that only hold other keys
+func configKeys() []string {
+	allKeys := v.AllKeys()
+	sort.Strings(allKeys)
+
+	var keys []string
+	for idx, key := range allKeys {
+		if key == V_PROFILES || strings.HasPrefix(key, V_PROFILES+".") {
+			continue
+		}
+		// Sorted keys are followed by their children, e.g. package.deploy.set by package.deploy.set.domain
+		if idx+1 < len(allKeys) && strings.HasPrefix(allKeys[idx+1], key+".") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// configEnvName returns the environment variable viper reads a config key from, e.g. ZARF_INIT.STORAGE_CLASS
+func configEnvName(key string) string {
+	return "ZARF_" + strings.ToUpper(key)
+}
+
+// isSensitiveConfig returns if a config key holds a password or secret that shouldn't be printed
+func isSensitiveConfig(key string) bool {
+	return strings.HasSuffix(key, "password") || strings.HasSuffix(key, "secret")
+}
+
 func init() {
 	initViper()
 
@@ -139,6 +275,8 @@ func init() {
 	prepareCmd.AddCommand(prepareComputeFileSha256sum)
 	prepareCmd.AddCommand(prepareFindImages)
 	prepareCmd.AddCommand(prepareGenerateConfigFile)
+	prepareCmd.AddCommand(prepareShowConfig)
+	prepareCmd.AddCommand(prepareLint)
 
 	v.SetDefault(V_PKG_CREATE_SET, map[string]string{})
 
@@ -146,5 +284,10 @@ func init() {
 	// use the package create config for this and reset it here to avoid overwriting the config.CreateOptions.SetVariables
 	prepareFindImages.Flags().StringToStringVar(&pkgConfig.CreateOpts.SetVariables, "set", v.GetStringMapString(V_PKG_CREATE_SET), "Specify package variables to set on the command line (KEY=value). Note, if using a config file, this will be set by [package.create.set].")
 
+	prepareLint.Flags().BoolVar(&lintConfirm, "confirm", false, "Lint the package for deploying with --confirm, where component groups must have a default component")
+
 	prepareTransformGitLinks.Flags().StringVar(&pkgConfig.InitOpts.GitServer.PushUsername, "git-account", config.ZarfGitPushUser, "User or organization name for the git account that the repos are created under.")
+	prepareTransformGitLinks.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Provider, "git-provider", config.ZarfGitProviderGitea, "Type of git server the repos are pushed to: gitea, gitlab or generic.")
+	prepareTransformGitLinks.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Group, "git-group", "", "Group or organization the repos are created under, defaults to the git-account.")
+	prepareTransformGitLinks.Flags().StringVar(&pkgConfig.InitOpts.GitServer.RepoNaming, "git-repo-naming", config.ZarfGitRepoNamingHash, "How repos are named in the git server: hash or flat.")
 }
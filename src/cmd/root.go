@@ -22,6 +22,7 @@ var (
 	skipLogFile bool
 	logLevel    string
 	arch        string
+	profile     string
 
 	// Default global config for the CLI
 	pkgConfig = types.PackagerConfig{}
@@ -72,6 +73,7 @@ func init() {
 	v.SetDefault(V_NO_PROGRESS, false)
 	v.SetDefault(V_ZARF_CACHE, config.ZarfDefaultCachePath)
 	v.SetDefault(V_TMP_DIR, "")
+	v.SetDefault(V_PROFILE, "")
 
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", v.GetString(V_LOG_LEVEL), lang.RootCmdFlagLogLevel)
 	rootCmd.PersistentFlags().StringVarP(&arch, "architecture", "a", v.GetString(V_ARCHITECTURE), lang.RootCmdFlagArch)
@@ -79,6 +81,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&message.NoProgress, "no-progress", v.GetBool(V_NO_PROGRESS), lang.RootCmdFlagNoProgress)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", v.GetString(V_ZARF_CACHE), lang.RootCmdFlagCachePath)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.TempDirectory, "tmpdir", v.GetString(V_TMP_DIR), lang.RootCmdFlagTempDir)
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", v.GetString(V_PROFILE), lang.RootCmdFlagProfile)
 }
 
 func cliSetup() {
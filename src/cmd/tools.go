@@ -7,21 +7,43 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/anchore/syft/cmd/syft/cli"
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/config/lang"
+	"github.com/defenseunicorns/zarf/src/internal/cache"
 	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/internal/packager/git"
+	"github.com/defenseunicorns/zarf/src/internal/packager/images"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/pki"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
 	k9s "github.com/derailed/k9s/cmd"
 	craneCmd "github.com/google/go-containerregistry/cmd/crane/cmd"
 	"github.com/mholt/archiver/v3"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 var subAltNames []string
 
+var (
+	mirrorRegistryInfo  types.RegistryInfo
+	mirrorUpdateState   bool
+	registryRemoveForce bool
+
+	pruneDryRun      bool
+	registryInsecure bool
+
+	cacheOlderThan time.Duration
+	cacheMaxSize   string
+
+	gitExportOutput string
+)
+
 var toolsCmd = &cobra.Command{
 	Use:     "tools",
 	Aliases: []string{"t"},
@@ -73,6 +95,193 @@ var registryCmd = &cobra.Command{
 	Short:   lang.CmdToolsRegistryShort,
 }
 
+var registryMirrorCmd = &cobra.Command{
+	Use:   "mirror",
+	Short: lang.CmdToolsRegistryMirrorShort,
+	Long:  lang.CmdToolsRegistryMirrorLong,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The state is only updated with credentials that work for the target registry, pulls default to the push user
+		if mirrorUpdateState {
+			if mirrorRegistryInfo.PushUsername == "" || mirrorRegistryInfo.PushPassword == "" {
+				message.Fatal(nil, lang.CmdToolsRegistryMirrorErrCreds)
+			}
+			if mirrorRegistryInfo.PullUsername == "" {
+				mirrorRegistryInfo.PullUsername = mirrorRegistryInfo.PushUsername
+				mirrorRegistryInfo.PullPassword = mirrorRegistryInfo.PushPassword
+			}
+		}
+
+		c := cluster.NewClusterOrDie()
+		imgConfig, deployedImages := loadZarfRegistryConfig(c, registryInsecure)
+
+		if len(deployedImages) < 1 {
+			message.Warn(lang.CmdToolsRegistryMirrorNoImages)
+			return
+		}
+
+		if err := imgConfig.MirrorToRegistry(deployedImages, mirrorRegistryInfo); err != nil {
+			message.Fatalf(err, lang.CmdToolsRegistryMirrorErr, mirrorRegistryInfo.Address)
+		}
+
+		if mirrorUpdateState {
+			if err := c.UpdateRegistryInfo(mirrorRegistryInfo); err != nil {
+				message.Fatal(err, lang.CmdToolsRegistryMirrorErrState)
+			}
+			message.SuccessF(lang.CmdToolsRegistryMirrorStateUpdated, mirrorRegistryInfo.Address)
+		}
+	},
+}
+
+var registryPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: lang.CmdToolsRegistryPruneShort,
+	Long:  lang.CmdToolsRegistryPruneLong,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !pruneDryRun && !config.CommonOptions.Confirm {
+			message.Fatal(nil, lang.CmdToolsRegistryPruneErrConfirm)
+		}
+
+		c := cluster.NewClusterOrDie()
+		imgConfig, deployedImages := loadZarfRegistryConfig(c, registryInsecure)
+
+		if !imgConfig.RegInfo.InternalRegistry {
+			message.Fatal(nil, lang.CmdToolsRegistryPruneErrExternal)
+		}
+
+		pruned, reclaimable, err := imgConfig.PruneRegistry(deployedImages, pruneDryRun)
+		if err != nil {
+			message.Fatal(err, lang.CmdToolsRegistryPruneErr)
+		}
+
+		if len(pruned) < 1 {
+			message.SuccessF(lang.CmdToolsRegistryPruneNothing)
+			return
+		}
+
+		// Populate a pterm table of all the pruned images
+		pruneTable := pterm.TableData{
+			{"     Repository", "Tags", "Digest", "Reclaimed"},
+		}
+		for _, candidate := range pruned {
+			pruneTable = append(pruneTable, pterm.TableData{{
+				fmt.Sprintf("     %s", candidate.Repository),
+				strings.Join(candidate.Tags, ", "),
+				candidate.Digest,
+				utils.ByteFormat(float64(candidate.Size), 2),
+			}}...)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(pruneTable).Render()
+
+		if pruneDryRun {
+			message.Notef(lang.CmdToolsRegistryPruneDryRun, len(pruned), utils.ByteFormat(float64(reclaimable), 2))
+			return
+		}
+
+		if err := c.RunRegistryGarbageCollection(); err != nil {
+			message.Fatal(err, lang.CmdToolsRegistryPruneErrGC)
+		}
+
+		message.SuccessF(lang.CmdToolsRegistryPruneSuccess, len(pruned), utils.ByteFormat(float64(reclaimable), 2))
+	},
+}
+
+var registryListCmd = &cobra.Command{
+	Use:     "ls [REPOSITORY]",
+	Aliases: []string{"list"},
+	Short:   lang.CmdToolsRegistryLsShort,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var repository string
+		if len(args) > 0 {
+			repository = args[0]
+		}
+
+		imgConfig, deployedImages := loadZarfRegistryConfig(cluster.NewClusterOrDie(), registryInsecure)
+
+		registryImages, err := imgConfig.ListRegistryImages(deployedImages, repository)
+		if err != nil {
+			message.Fatal(err, lang.CmdToolsRegistryLsErr)
+		}
+
+		// Populate a pterm table of all the images in the registry
+		imageTable := pterm.TableData{
+			{"     Repository", "Tag", "Size", "Original Image", "Packages"},
+		}
+		for _, image := range registryImages {
+			imageTable = append(imageTable, pterm.TableData{{
+				fmt.Sprintf("     %s", image.Repository),
+				image.Tag,
+				utils.ByteFormat(float64(image.Size), 2),
+				image.Source,
+				strings.Join(image.Packages, ", "),
+			}}...)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(imageTable).Render()
+	},
+}
+
+var registryInspectCmd = &cobra.Command{
+	Use:   "inspect {IMAGE}",
+	Short: lang.CmdToolsRegistryInspectShort,
+	Long:  lang.CmdToolsRegistryInspectLong,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		imgConfig, deployedImages := loadZarfRegistryConfig(cluster.NewClusterOrDie(), registryInsecure)
+
+		details, err := imgConfig.InspectRegistryImage(deployedImages, args[0])
+		if err != nil {
+			message.Fatalf(err, lang.CmdToolsRegistryInspectErr, args[0])
+		}
+
+		detailsTable := pterm.TableData{
+			{"     Repository", details.Repository},
+			{"     Tag", details.Tag},
+			{"     Digest", details.Digest},
+			{"     Size", utils.ByteFormat(float64(details.Size), 2)},
+			{"     Layers", fmt.Sprintf("%d", len(details.Manifest.Layers))},
+			{"     Original Image", details.Source},
+			{"     Packages", strings.Join(details.Packages, ", ")},
+		}
+		if details.Config != nil {
+			detailsTable = append(detailsTable, pterm.TableData{
+				{"     Platform", fmt.Sprintf("%s/%s", details.Config.OS, details.Config.Architecture)},
+				{"     Created", details.Config.Created.String()},
+			}...)
+		}
+		_ = pterm.DefaultTable.WithData(detailsTable).Render()
+
+		pterm.Println()
+		utils.ColorPrintYAML(details.Manifest)
+	},
+}
+
+var registryRemoveCmd = &cobra.Command{
+	Use:     "rm {IMAGE}",
+	Aliases: []string{"delete"},
+	Short:   lang.CmdToolsRegistryRmShort,
+	Long:    lang.CmdToolsRegistryRmLong,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !config.CommonOptions.Confirm {
+			message.Fatal(nil, lang.CmdToolsRegistryRmErrConfirm)
+		}
+
+		c := cluster.NewClusterOrDie()
+		imgConfig, deployedImages := loadZarfRegistryConfig(c, registryInsecure)
+
+		if err := imgConfig.DeleteRegistryImage(deployedImages, args[0], registryRemoveForce); err != nil {
+			message.Fatalf(err, lang.CmdToolsRegistryRmErr, args[0])
+		}
+
+		// Only the registry Zarf manages can be garbage collected
+		if imgConfig.RegInfo.InternalRegistry {
+			if err := c.RunRegistryGarbageCollection(); err != nil {
+				message.Fatal(err, lang.CmdToolsRegistryPruneErrGC)
+			}
+		}
+	},
+}
+
 var readCredsCmd = &cobra.Command{
 	Use:   "get-git-password",
 	Short: lang.CmdToolsGetGitPasswdShort,
@@ -89,6 +298,108 @@ var readCredsCmd = &cobra.Command{
 	},
 }
 
+var gitCmd = &cobra.Command{
+	Use:     "git",
+	Aliases: []string{"g"},
+	Short:   lang.CmdToolsGitShort,
+}
+
+var gitListCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   lang.CmdToolsGitLsShort,
+	Run: func(cmd *cobra.Command, args []string) {
+		gitClient, closeTunnel := loadZarfGitClient(cluster.NewClusterOrDie())
+		defer closeTunnel()
+
+		repos, err := gitClient.ListMirroredRepos()
+		if err != nil {
+			message.Fatal(err, lang.CmdToolsGitLsErr)
+		}
+
+		if len(repos) < 1 {
+			message.Note(lang.CmdToolsGitLsEmpty)
+			return
+		}
+
+		// Populate a pterm table of the repos and what they mirror
+		repoTable := pterm.TableData{
+			{"     Upstream Repo", "Mirrored Repo"},
+		}
+		for _, repo := range repos {
+			source := repo.Source
+			if source == "" {
+				source = lang.CmdToolsGitUnknownSrc
+			}
+			repoTable = append(repoTable, pterm.TableData{{
+				fmt.Sprintf("     %s", source),
+				repo.Name,
+			}}...)
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(repoTable).Render()
+	},
+}
+
+var gitExportCmd = &cobra.Command{
+	Use:   "export {REPO}",
+	Short: lang.CmdToolsGitExportShort,
+	Long:  lang.CmdToolsGitExportLong,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c := cluster.NewClusterOrDie()
+		gitClient, closeTunnel := loadZarfGitClient(c)
+		defer closeTunnel()
+
+		repoName, err := gitClient.MirrorRepoName(args[0])
+		if err != nil {
+			message.Fatalf(err, lang.CmdToolsGitExportErr, args[0])
+		}
+
+		deployedPackages, err := c.GetDeployedZarfPackages()
+		if err != nil {
+			message.Fatal(err, lang.CmdToolsGitExportErrPkgs)
+		}
+
+		// Everything any package pushed to the repo is already upstream
+		var basis []string
+		for _, deployedPackage := range deployedPackages {
+			for _, component := range deployedPackage.DeployedComponents {
+				for _, pushed := range component.PushedRepos {
+					if pushed.Name != repoName {
+						continue
+					}
+					for _, hash := range pushed.Refs {
+						basis = append(basis, hash)
+					}
+				}
+			}
+		}
+		if len(basis) == 0 {
+			message.Warnf(lang.CmdToolsGitExportNoBasis, repoName)
+		}
+
+		outputPath := gitExportOutput
+		if outputPath == "" {
+			outputPath = repoName + ".bundle"
+		}
+
+		exported, err := gitClient.ExportBundle(repoName, basis, outputPath)
+		if err != nil {
+			message.Fatalf(err, lang.CmdToolsGitExportErr, repoName)
+		}
+
+		if len(exported) == 0 {
+			message.Notef(lang.CmdToolsGitExportNothing, repoName)
+			return
+		}
+
+		for _, ref := range exported {
+			message.Info(ref)
+		}
+		message.SuccessF(lang.CmdToolsGitExportSuccess, len(exported), repoName, outputPath)
+	},
+}
+
 var k9sCmd = &cobra.Command{
 	Use:     "monitor",
 	Aliases: []string{"m", "k9s"},
@@ -113,6 +424,81 @@ var clearCacheCmd = &cobra.Command{
 	},
 }
 
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: lang.CmdToolsCacheShort,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   lang.CmdToolsCacheListShort,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, err := cache.List()
+		if err != nil {
+			message.Fatalf(err, lang.CmdToolsCacheListErr, config.GetAbsCachePath())
+		}
+
+		if len(entries) == 0 {
+			message.Infof(lang.CmdToolsCacheListEmpty, config.GetAbsCachePath())
+			return
+		}
+
+		var total int64
+		list := pterm.TableData{{"     Kind", "Name", "Size", "Last Used"}}
+		for _, entry := range entries {
+			total += entry.Size
+			list = append(list, pterm.TableData{{
+				fmt.Sprintf("     %s", entry.Kind),
+				entry.Name,
+				utils.ByteFormat(float64(entry.Size), 2),
+				entry.LastUsed.Format(time.RFC822),
+			}}...)
+		}
+
+		_ = pterm.DefaultTable.WithHasHeader().WithData(list).Render()
+		message.Infof(lang.CmdToolsCacheListTotal, utils.ByteFormat(float64(total), 2))
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: lang.CmdToolsCachePruneShort,
+	Long:  lang.CmdToolsCachePruneLong,
+	Run: func(cmd *cobra.Command, args []string) {
+		var maxSize int64
+		var err error
+
+		if cacheOlderThan == 0 && cacheMaxSize == "" {
+			message.Fatal(nil, lang.CmdToolsCachePruneErrFlags)
+		}
+
+		if cacheMaxSize != "" {
+			if maxSize, err = cache.ParseSize(cacheMaxSize); err != nil {
+				message.Fatal(err, lang.CmdToolsCachePruneErrSize)
+			}
+		}
+
+		removed, err := cache.Prune(cacheOlderThan, maxSize)
+		if err != nil {
+			message.Fatalf(err, lang.CmdToolsCachePruneErr, config.GetAbsCachePath())
+		}
+
+		if len(removed) == 0 {
+			message.Info(lang.CmdToolsCachePruneNothing)
+			return
+		}
+
+		var reclaimed int64
+		for _, entry := range removed {
+			message.Debugf("Removed %s %s (%s)", entry.Kind, entry.Name, utils.ByteFormat(float64(entry.Size), 2))
+			reclaimed += entry.Size
+		}
+
+		message.SuccessF(lang.CmdToolsCachePruneSuccess, len(removed), utils.ByteFormat(float64(reclaimed), 2))
+	},
+}
+
 var generatePKICmd = &cobra.Command{
 	Use:     "gen-pki {HOST}",
 	Aliases: []string{"pki"},
@@ -133,16 +519,74 @@ var generatePKICmd = &cobra.Command{
 	},
 }
 
+// loadZarfGitClient returns a git client for the Zarf git server, connecting through a tunnel if the server is in the cluster
+func loadZarfGitClient(c *cluster.Cluster) (*git.Git, func()) {
+	state, err := c.LoadZarfState()
+	if err != nil || state.Distro == "" {
+		// If no distro the zarf secret did not load properly
+		message.Fatalf(nil, lang.ErrLoadState)
+	}
+
+	gitServer := state.GitServer
+	closeTunnel := func() {}
+
+	// If this is a serviceURL, create a port-forward tunnel to that resource
+	if cluster.IsServiceURL(gitServer.Address) {
+		tunnel, err := cluster.NewTunnelFromServiceURL(gitServer.Address)
+		if err != nil {
+			message.Fatal(err, lang.CmdToolsGitErrTunnel)
+		}
+		if err := tunnel.Connect("", false); err != nil {
+			message.Fatal(err, lang.CmdToolsGitErrTunnel)
+		}
+		closeTunnel = tunnel.Close
+		gitServer.Address = fmt.Sprintf("http://%s", tunnel.Endpoint())
+	}
+
+	return git.New(gitServer), closeTunnel
+}
+
+// loadZarfRegistryConfig returns an image config for the Zarf registry and the images recorded by the deployed packages
+func loadZarfRegistryConfig(c *cluster.Cluster, insecure bool) (images.ImgConfig, []images.DeployedImage) {
+	state, err := c.LoadZarfState()
+	if err != nil || state.Distro == "" {
+		// If no distro the zarf secret did not load properly
+		message.Fatalf(nil, lang.ErrLoadState)
+	}
+
+	deployedPackages, err := c.GetDeployedZarfPackages()
+	if err != nil {
+		message.Fatal(err, lang.CmdToolsRegistryErrPackages)
+	}
+
+	imgConfig := images.ImgConfig{
+		RegInfo:  state.RegistryInfo,
+		Insecure: insecure,
+	}
+
+	return imgConfig, images.GetDeployedImages(deployedPackages)
+}
+
 func init() {
 	rootCmd.AddCommand(toolsCmd)
 	toolsCmd.AddCommand(archiverCmd)
 	toolsCmd.AddCommand(readCredsCmd)
 	toolsCmd.AddCommand(k9sCmd)
 	toolsCmd.AddCommand(registryCmd)
+	toolsCmd.AddCommand(gitCmd)
+	gitCmd.AddCommand(gitListCmd)
+	gitCmd.AddCommand(gitExportCmd)
+	gitExportCmd.Flags().StringVarP(&gitExportOutput, "output", "o", "", lang.CmdToolsGitExportFlagOut)
 
 	toolsCmd.AddCommand(clearCacheCmd)
 	clearCacheCmd.Flags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", config.ZarfDefaultCachePath, lang.CmdToolsClearCacheFlagCachePath)
 
+	toolsCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+	cachePruneCmd.Flags().DurationVar(&cacheOlderThan, "older-than", 0, lang.CmdToolsCachePruneFlagOlder)
+	cachePruneCmd.Flags().StringVar(&cacheMaxSize, "max-size", "", lang.CmdToolsCachePruneFlagMax)
+
 	toolsCmd.AddCommand(generatePKICmd)
 	generatePKICmd.Flags().StringArrayVar(&subAltNames, "sub-alt-name", []string{}, lang.CmdToolsGenPkiFlagAltName)
 
@@ -163,6 +607,32 @@ func init() {
 	registryCmd.AddCommand(craneCmd.NewCmdCopy(&cranePlatformOptions))
 	registryCmd.AddCommand(craneCatalog)
 
+	registryCmd.AddCommand(registryMirrorCmd)
+	registryMirrorCmd.Flags().StringVar(&mirrorRegistryInfo.Address, "to", "", lang.CmdToolsRegistryMirrorFlagTo)
+	registryMirrorCmd.Flags().StringVar(&mirrorRegistryInfo.PushUsername, "to-push-username", "", lang.CmdToolsRegistryMirrorFlagPushUser)
+	registryMirrorCmd.Flags().StringVar(&mirrorRegistryInfo.PushPassword, "to-push-password", "", lang.CmdToolsRegistryMirrorFlagPushPass)
+	registryMirrorCmd.Flags().StringVar(&mirrorRegistryInfo.PullUsername, "to-pull-username", "", lang.CmdToolsRegistryMirrorFlagPullUser)
+	registryMirrorCmd.Flags().StringVar(&mirrorRegistryInfo.PullPassword, "to-pull-password", "", lang.CmdToolsRegistryMirrorFlagPullPass)
+	registryMirrorCmd.Flags().BoolVar(&mirrorUpdateState, "update-state", false, lang.CmdToolsRegistryMirrorFlagUpdate)
+	registryMirrorCmd.Flags().BoolVar(&registryInsecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+	_ = registryMirrorCmd.MarkFlagRequired("to")
+
+	registryCmd.AddCommand(registryPruneCmd)
+	registryPruneCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsRegistryPruneFlagConfirm)
+	registryPruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, lang.CmdToolsRegistryPruneFlagDryRun)
+	registryPruneCmd.Flags().BoolVar(&registryInsecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+
+	registryCmd.AddCommand(registryListCmd)
+	registryListCmd.Flags().BoolVar(&registryInsecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+
+	registryCmd.AddCommand(registryInspectCmd)
+	registryInspectCmd.Flags().BoolVar(&registryInsecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+
+	registryCmd.AddCommand(registryRemoveCmd)
+	registryRemoveCmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsRegistryRmFlagConfirm)
+	registryRemoveCmd.Flags().BoolVar(&registryRemoveForce, "force", false, lang.CmdToolsRegistryRmFlagForce)
+	registryRemoveCmd.Flags().BoolVar(&registryInsecure, "insecure", false, lang.CmdToolsRegistryFlagInsecure)
+
 	syftCmd, err := cli.New()
 	if err != nil {
 		message.Fatal(err, lang.CmdToolsSbomErr)
@@ -5,10 +5,14 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config/lang"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
@@ -20,6 +24,11 @@ const (
 	V_NO_PROGRESS  = "no_progress"
 	V_ZARF_CACHE   = "zarf_cache"
 	V_TMP_DIR      = "tmp_dir"
+	V_PROFILE      = "profile"
+
+	// Profile config keys
+	V_PROFILES        = "profiles"
+	V_PROFILE_EXTENDS = "extends"
 
 	// Init config keys
 	V_INIT_COMPONENTS    = "init.components"
@@ -31,6 +40,9 @@ const (
 	V_INIT_GIT_PUSH_PASS = "init.git.push_password"
 	V_INIT_GIT_PULL_USER = "init.git.pull_username"
 	V_INIT_GIT_PULL_PASS = "init.git.pull_password"
+	V_INIT_GIT_PROVIDER  = "init.git.provider"
+	V_INIT_GIT_GROUP     = "init.git.group"
+	V_INIT_GIT_NAMING    = "init.git.repo_naming"
 
 	// Init Registry config keys
 	V_INIT_REGISTRY_URL       = "init.registry.url"
@@ -48,15 +60,26 @@ const (
 	V_PKG_CREATE_SBOM_OUTPUT = "package.create.sbom_output"
 	V_PKG_CREATE_SKIP_SBOM   = "package.create.skip_sbom"
 	V_PKG_CREATE_INSECURE    = "package.create.insecure"
+	V_PKG_CREATE_MAX_CACHE   = "package.create.max_cache_size"
+	V_PKG_CREATE_VULN_DB     = "package.create.vuln_db"
+	V_PKG_CREATE_VULN_FAIL   = "package.create.vuln_fail_on"
 
 	// Package deploy config keys
-	V_PKG_DEPLOY_SET        = "package.deploy.set"
-	V_PKG_DEPLOY_COMPONENTS = "package.deploy.components"
-	V_PKG_DEPLOY_INSECURE   = "package.deploy.insecure"
-	V_PKG_DEPLOY_SHASUM     = "package.deploy.shasum"
-	V_PKG_DEPLOY_SGET       = "package.deploy.sget"
+	V_PKG_DEPLOY_SET          = "package.deploy.set"
+	V_PKG_DEPLOY_COMPONENTS   = "package.deploy.components"
+	V_PKG_DEPLOY_INSECURE     = "package.deploy.insecure"
+	V_PKG_DEPLOY_SHASUM       = "package.deploy.shasum"
+	V_PKG_DEPLOY_SGET         = "package.deploy.sget"
+	V_PKG_DEPLOY_VALUES       = "package.deploy.values"
+	V_PKG_DEPLOY_RESET_VALUES = "package.deploy.reset_values"
 )
 
+// activeProfiles are the names of the config profiles applied to the config, from the furthest ancestor to the selected profile
+var activeProfiles []string
+
+// profileSettings are the settings of each applied config profile (without their extends key)
+var profileSettings = make(map[string]map[string]any)
+
 func initViper() {
 	// Already initializedby some other command
 	if v != nil {
@@ -96,4 +119,109 @@ func initViper() {
 	} else {
 		message.Notef(lang.CmdViperInfoUsingConfigFile, v.ConfigFileUsed())
 	}
+
+	// The profile is needed before the flags are parsed as the flag defaults are read from the config
+	profile := profileFromArgs(os.Args[1:])
+	if profile == "" {
+		profile = v.GetString(V_PROFILE)
+	}
+	if profile != "" {
+		if err := applyProfile(profile); err != nil {
+			message.Fatalf(err, lang.CmdViperErrApplyingProfile, profile, err.Error())
+		}
+		message.Notef(lang.CmdViperInfoUsingProfile, strings.Join(activeProfiles, " > "))
+	}
+}
+
+// validateConfigSetValues rejects the helm values (component.chart.path keys) of package.deploy.set in the config file,
+// viper lowercases config keys so paths like replicaCount would not match the chart's values
+func validateConfigSetValues(cmd *cobra.Command) error {
+	// The flag replaces the config map
+	if cmd.Flags().Changed("set") {
+		return nil
+	}
+
+	for key := range v.GetStringMapString(V_PKG_DEPLOY_SET) {
+		if strings.Contains(key, ".") {
+			return fmt.Errorf(lang.CmdViperErrChartSetKey, key)
+		}
+	}
+
+	return nil
+}
+
+// profileFromArgs finds the value of the --profile flag in the command line arguments
+func profileFromArgs(args []string) string {
+	for idx, arg := range args {
+		// Anything after -- is an argument and not a flag
+		if arg == "--" {
+			break
+		}
+		if arg == "--profile" && idx+1 < len(args) {
+			return args[idx+1]
+		}
+		if strings.HasPrefix(arg, "--profile=") {
+			return strings.TrimPrefix(arg, "--profile=")
+		}
+	}
+	return ""
+}
+
+// applyProfile merges a profile and the profiles it extends over the config file
+func applyProfile(name string) error {
+	profiles := cast.ToStringMap(v.Get(V_PROFILES))
+
+	// The chain is built from the selected profile up to the furthest ancestor
+	var chain []string
+	for name != "" {
+		// Viper keys are case insensitive
+		name = strings.ToLower(name)
+		for _, seen := range chain {
+			if seen == name {
+				return fmt.Errorf("profile %s extends itself through %s > %s", name, strings.Join(chain, " > "), name)
+			}
+		}
+
+		profile, ok := profiles[name]
+		if !ok {
+			return fmt.Errorf("profile %s is not defined in the profiles of the config file", name)
+		}
+
+		settings := make(map[string]any)
+		for key, value := range cast.ToStringMap(profile) {
+			settings[key] = value
+		}
+		extends := cast.ToString(settings[V_PROFILE_EXTENDS])
+		delete(settings, V_PROFILE_EXTENDS)
+
+		chain = append(chain, name)
+		profileSettings[name] = settings
+		name = extends
+	}
+
+	// Merge from the furthest ancestor so profiles override the profiles they extend
+	for idx := len(chain) - 1; idx >= 0; idx-- {
+		name := chain[idx]
+		if err := v.MergeConfigMap(profileSettings[name]); err != nil {
+			return fmt.Errorf("unable to merge profile %s: %w", name, err)
+		}
+		activeProfiles = append(activeProfiles, name)
+	}
+
+	return nil
+}
+
+// profileSetting returns the name of the closest applied profile that sets a config key
+func profileSetting(key string) (string, bool) {
+	for idx := len(activeProfiles) - 1; idx >= 0; idx-- {
+		name := activeProfiles[idx]
+		var value any = profileSettings[name]
+		for _, part := range strings.Split(key, ".") {
+			value = cast.ToStringMap(value)[part]
+		}
+		if value != nil {
+			return name, true
+		}
+	}
+	return "", false
 }
@@ -44,8 +44,9 @@ const (
 	ZarfManagedByLabel     = "app.kubernetes.io/managed-by"
 	ZarfCleanupScriptsPath = "/opt/zarf"
 
-	ZarfImageCacheDir = "images"
-	ZarfGitCacheDir   = "repos"
+	ZarfImageCacheDir     = "images"
+	ZarfGitCacheDir       = "repos"
+	ZarfComponentCacheDir = "components"
 
 	ZarfYAML    = "zarf.yaml"
 	ZarfSBOMDir = "zarf-sbom"
@@ -53,8 +54,29 @@ const (
 	ZarfInClusterContainerRegistryURL      = "http://zarf-registry-http.zarf.svc.cluster.local:5000"
 	ZarfInClusterContainerRegistryNodePort = 31999
 
+	// The path in the registry that helm charts are pushed to as OCI artifacts
+	ZarfRegistryChartsPath = "helm-charts"
+
 	ZarfInClusterGitServiceURL = "http://zarf-gitea-http.zarf.svc.cluster.local:3000"
 
+	// The git servers Zarf knows how to push repos to
+	ZarfGitProviderGitea   = "gitea"
+	ZarfGitProviderGitLab  = "gitlab"
+	ZarfGitProviderGeneric = "generic"
+
+	// The types of value a package variable accepts
+	ZarfVariableTypeString    = "string"
+	ZarfVariableTypeInt       = "int"
+	ZarfVariableTypeBool      = "bool"
+	ZarfVariableTypeEnum      = "enum"
+	ZarfVariableTypeFile      = "file"
+	ZarfVariableTypeMultiline = "multiline"
+	ZarfVariableTypeSecret    = "secret"
+
+	// How mirrored repos are named on the git server
+	ZarfGitRepoNamingHash = "hash"
+	ZarfGitRepoNamingFlat = "flat"
+
 	ZarfSeedImage = "registry"
 	ZarfSeedTag   = "2.8.1"
 )
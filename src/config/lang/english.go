@@ -37,6 +37,7 @@ const (
 	RootCmdFlagNoProgress  = "Disable fancy UI progress bars, spinners, logos, etc"
 	RootCmdFlagCachePath   = "Specify the location of the Zarf cache directory"
 	RootCmdFlagTempDir     = "Specify the temporary directory to use for intermediate files"
+	RootCmdFlagProfile     = "Name of the profile in the config file to apply (e.g. staging), profiles override the config file and are overridden by flags and environment variables"
 
 	RootCmdDeprecatedDeploy = "Please use \"zarf package deploy %s\" to deploy this package."
 	RootCmdDeprecatedCreate = "Please use \"zarf package create\" to create this package."
@@ -109,6 +110,7 @@ const (
 
 	CmdInitErrFlags            = "Invalid command flags were provided."
 	CmdInitErrDownload         = "failed to download the init package: %w"
+	CmdInitErrValidateGitProv  = "the 'git-provider' flag requires the 'git-url' flag: %s"
 	CmdInitErrValidateGit      = "the 'git-push-username' and 'git-push-password' flags must be provided if the 'git-url' flag is provided"
 	CmdInitErrValidateRegistry = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided "
 
@@ -127,6 +129,9 @@ const (
 	CmdInitFlagGitPushPass = "Password for the push-user to access the git server"
 	CmdInitFlagGitPullUser = "Username for pull-only access to the git server"
 	CmdInitFlagGitPullPass = "Password for the pull-only user to access the git server"
+	CmdInitFlagGitProvider = "Type of the external git server: gitea, gitlab (the push-password must be an access token with the api scope) or generic (a plain smart HTTP server)"
+	CmdInitFlagGitNaming   = "How repos are named in the git server: hash (repo-<checksum of the URL>, the default) or flat (host-org-repo, readable in the git server UI)"
+	CmdInitFlagGitGroup    = "Group or organization to create the repos under on the external git server, defaults to the push-user"
 
 	CmdInitFlagRegURL      = "External registry url address to use for this Zarf cluster"
 	CmdInitFlagRegNodePort = "Nodeport to access a registry internal to the k8s cluster. Between [30000-32767]"
@@ -147,10 +152,83 @@ const (
 
 	CmdToolsRegistryShort = "Tools for working with container registries using go-containertools."
 
+	CmdToolsRegistryErrPackages  = "Unable to get the packages deployed to the cluster"
+	CmdToolsRegistryFlagInsecure = "Allow insecure connections to the registries"
+
+	CmdToolsRegistryLsShort = "Lists the images in the Zarf registry along with their original image and the packages that shipped them"
+	CmdToolsRegistryLsErr   = "Unable to list the images in the Zarf registry"
+
+	CmdToolsRegistryInspectShort = "Shows the manifest, config and size of an image in the Zarf registry"
+	CmdToolsRegistryInspectLong  = "Shows the manifest, config and size of an image in the Zarf registry.\n\n" +
+		"The image can be given either by its name in the Zarf registry (e.g. library/nginx-3793515731:1.23) " +
+		"or by the original image reference of a deployed package (e.g. nginx:1.23)."
+	CmdToolsRegistryInspectErr = "Unable to inspect the image %s"
+
+	CmdToolsRegistryRmShort = "Deletes an image from the Zarf registry"
+	CmdToolsRegistryRmLong  = "Deletes an image from the Zarf registry and garbage collects the registry storage.\n\n" +
+		"The image can be given either by its name in the Zarf registry (e.g. library/nginx-3793515731:1.23) " +
+		"or by the original image reference of a deployed package (e.g. nginx:1.23).\n\n" +
+		"NOTE: Deletion is per manifest: the image is deleted by digest, so every tag pointing to the same manifest is removed as well. " +
+		"If any of those tags is used by a deployed package the deletion is refused unless --force is given."
+	CmdToolsRegistryRmErr         = "Unable to delete the image %s"
+	CmdToolsRegistryRmErrConfirm  = "Removing an image deletes it from the registry, re-run with --confirm to continue"
+	CmdToolsRegistryRmFlagConfirm = "Confirm the image deletion without prompting"
+	CmdToolsRegistryRmFlagForce   = "Delete the image even if a tag of its manifest is used by a deployed package"
+
+	CmdToolsRegistryMirrorShort = "Copies the images of all deployed packages from the Zarf registry to another registry"
+	CmdToolsRegistryMirrorLong  = "Reads the packages deployed to the cluster and copies every image they recorded from the Zarf registry " +
+		"into the registry given by --to, using the same image naming the Zarf agent uses.\n\n" +
+		"With --update-state the Zarf state and image pull secrets are pointed at the new registry once the mirror completes. " +
+		"This requires the push credentials of the new registry, which are also used to pull unless pull credentials are given."
+	CmdToolsRegistryMirrorErr          = "Unable to mirror the deployed images to %s"
+	CmdToolsRegistryMirrorErrCreds     = "Updating the Zarf state requires the credentials of the target registry, provide them with --to-push-username and --to-push-password"
+	CmdToolsRegistryMirrorErrState     = "Unable to update the Zarf state with the new registry information"
+	CmdToolsRegistryMirrorNoImages     = "No images were found in the packages deployed to this cluster"
+	CmdToolsRegistryMirrorStateUpdated = "Zarf is now configured to use the registry at %s"
+	CmdToolsRegistryMirrorFlagTo       = "REQUIRED. The registry address to copy the images to"
+	CmdToolsRegistryMirrorFlagPushUser = "Username with push access to the target registry"
+	CmdToolsRegistryMirrorFlagPushPass = "Password for the push-user of the target registry"
+	CmdToolsRegistryMirrorFlagPullUser = "Username with pull-only access to the target registry, used when updating the Zarf state"
+	CmdToolsRegistryMirrorFlagPullPass = "Password for the pull-only user of the target registry, used when updating the Zarf state"
+	CmdToolsRegistryMirrorFlagUpdate   = "Point the Zarf state and image pull secrets at the target registry after mirroring"
+
+	CmdToolsRegistryPruneShort = "Deletes images from the Zarf registry that are no longer referenced by a deployed package"
+	CmdToolsRegistryPruneLong  = "Compares the images in the Zarf registry against the images recorded by every package deployed to the cluster, " +
+		"deletes the manifests no deployed package references and then runs garbage collection in the registry to reclaim the space.\n\n" +
+		"NOTE: Images pushed to the registry outside of a Zarf package deployment are not tracked and will also be removed."
+	CmdToolsRegistryPruneErr         = "Unable to prune the Zarf registry"
+	CmdToolsRegistryPruneErrConfirm  = "Pruning deletes images from the registry, re-run with --confirm or use --dry-run to list what would be removed"
+	CmdToolsRegistryPruneErrExternal = "Pruning is only supported for the registry Zarf manages inside the cluster"
+	CmdToolsRegistryPruneErrGC       = "Unable to garbage collect the Zarf registry"
+	CmdToolsRegistryPruneNothing     = "All images in the Zarf registry are referenced by a deployed package"
+	CmdToolsRegistryPruneDryRun      = "%d images would be removed, reclaiming %s"
+	CmdToolsRegistryPruneSuccess     = "Removed %d images, reclaiming %s"
+	CmdToolsRegistryPruneFlagConfirm = "Confirm the image deletion without prompting"
+	CmdToolsRegistryPruneFlagDryRun  = "List the images that would be removed and the space that would be reclaimed without deleting anything"
+
 	CmdToolsGetGitPasswdShort = "Returns the push user's password for the Git server"
 	CmdToolsGetGitPasswdLong  = "Reads the password for a user with push access to the configured Git server from the zarf-state secret in the zarf namespace"
 	CmdToolsGetGitPasswdInfo  = "Git Server Push Password: "
 
+	CmdToolsGitShort      = "Tools to work with the repos in the Zarf git server"
+	CmdToolsGitLsShort    = "Lists the repos in the Zarf git server along with the upstream repo each one mirrors"
+	CmdToolsGitLsErr      = "Unable to list the repos in the Zarf git server"
+	CmdToolsGitLsEmpty    = "No repos found in the Zarf git server"
+	CmdToolsGitErrTunnel  = "Unable to connect to the Zarf git server"
+	CmdToolsGitUnknownSrc = "(unknown)"
+
+	CmdToolsGitExportShort = "Bundles the commits made in the Zarf git server to a repo into a git bundle file"
+	CmdToolsGitExportLong  = "Bundles the branches and tags of a repo in the Zarf git server that changed since they were deployed into a git bundle file.\n" +
+		"The repo can be given by its name in the git server (see 'zarf tools git ls') or by its upstream URL.\n" +
+		"Carry the bundle back to the connected side and fetch it into a clone of the upstream repo, i.e.:\n" +
+		"  git fetch podinfo.bundle 'refs/heads/*:refs/remotes/airgap/*'"
+	CmdToolsGitExportFlagOut = "Path to write the git bundle to, defaults to <repo>.bundle"
+	CmdToolsGitExportErr     = "Unable to export %s from the Zarf git server"
+	CmdToolsGitExportNoBasis = "No deployed package records what was pushed to %s, the bundle will contain its full history"
+	CmdToolsGitExportNothing = "No branches or tags of %s changed in the Zarf git server since they were deployed"
+	CmdToolsGitExportSuccess = "Exported %d refs of %s to %s"
+	CmdToolsGitExportErrPkgs = "Unable to get the packages deployed to the cluster"
+
 	CmdToolsMonitorShort = "Launch a terminal UI to monitor the connected cluster using K9s."
 
 	CmdToolsClearCacheShort         = "Clears the configured git and image cache directory."
@@ -158,6 +236,22 @@ const (
 	CmdToolsClearCacheSuccess       = "Successfully cleared the cache from %s"
 	CmdToolsClearCacheFlagCachePath = "Specify the location of the Zarf  artifact cache (images and git repositories)"
 
+	CmdToolsCacheShort      = "Inspect and trim the Zarf image, git repository and component import cache"
+	CmdToolsCacheListShort  = "Lists the images, git repositories, component imports and shared image layers in the cache, least recently used first"
+	CmdToolsCacheListErr    = "Unable to read the cache directory %s"
+	CmdToolsCacheListEmpty  = "The cache at %s is empty"
+	CmdToolsCacheListTotal  = "Total cache size: %s"
+	CmdToolsCachePruneShort = "Removes cache entries that have not been used recently or that exceed a maximum cache size"
+	CmdToolsCachePruneLong  = "Removes cache entries last used before --older-than, then removes the least recently used entries until the cache is at or below --max-size.\n" +
+		"Image layers shared by several cached images are only removed once no remaining image needs them."
+	CmdToolsCachePruneErr       = "Unable to prune the cache directory %s"
+	CmdToolsCachePruneErrFlags  = "Specify --older-than and/or --max-size to prune the cache"
+	CmdToolsCachePruneErrSize   = "Unable to parse the --max-size value"
+	CmdToolsCachePruneNothing   = "Nothing to prune from the cache"
+	CmdToolsCachePruneSuccess   = "Removed %d cache entries, reclaiming %s"
+	CmdToolsCachePruneFlagOlder = "Remove entries not used within this duration (e.g. 720h)"
+	CmdToolsCachePruneFlagMax   = "Remove the least recently used entries until the cache is at or below this size (e.g. 20Gi)"
+
 	CmdToolsGenPkiShort       = "Generates a Certificate Authority and PKI chain of trust for the given host"
 	CmdToolsGenPkiSuccess     = "Successfully created a chain of trust for %s"
 	CmdToolsGenPkiFlagAltName = "Specify Subject Alternative Names for the certificate"
@@ -172,6 +266,9 @@ const (
 	// cmd viper setup
 	CmdViperErrLoadingConfigFile = "failed to load config file: %w"
 	CmdViperInfoUsingConfigFile  = "Using config file %s"
+	CmdViperInfoUsingProfile     = "Using config profile %s"
+	CmdViperErrApplyingProfile   = "Unable to apply the config profile %s: %s"
+	CmdViperErrChartSetKey       = "the helm value %s can't be set with package.deploy.set in the config file as config keys are lowercased, use --set or a values file in package.deploy.values instead"
 )
 
 // Zarf Agent messages
@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cache contains functions for managing the Zarf image, git repository and imported component cache
+package cache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	KindImage     = "image"
+	KindRepo      = "repo"
+	KindLayer     = "layer"
+	KindComponent = "component"
+
+	indexFileName = "zarf-cache-index.yaml"
+)
+
+// Entry is an image, git repository, imported component source or untracked image layer stored in the Zarf cache
+type Entry struct {
+	Kind     string
+	Name     string
+	Size     int64
+	LastUsed time.Time
+
+	// The files or directories on disk that make up this entry
+	paths []string
+}
+
+// index tracks which cached image layers belong to which image, the filesystem cache only knows about layers
+type index struct {
+	Images map[string]indexedImage `json:"images"`
+}
+
+type indexedImage struct {
+	Layers   []string  `json:"layers"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+var indexLock sync.Mutex
+
+// GetImageCachePath returns the directory image layers are cached in
+func GetImageCachePath() string {
+	return filepath.Join(config.GetAbsCachePath(), config.ZarfImageCacheDir)
+}
+
+// GetRepoCachePath returns the directory git repositories are cached in
+func GetRepoCachePath() string {
+	return filepath.Join(config.GetAbsCachePath(), config.ZarfGitCacheDir)
+}
+
+// GetComponentCachePath returns the directory remote component imports are cached in
+func GetComponentCachePath() string {
+	return filepath.Join(config.GetAbsCachePath(), config.ZarfComponentCacheDir)
+}
+
+// ParseSize converts a size such as 500Mi, 20Gi or 20G into bytes
+func ParseSize(size string) (int64, error) {
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %s: %w", size, err)
+	}
+	return quantity.Value(), nil
+}
+
+// RecordImageUse records the layers of an image and marks the image and its layers as just used
+func RecordImageUse(image string, layers []string) error {
+	indexLock.Lock()
+	defer indexLock.Unlock()
+
+	idx := readIndex()
+	now := time.Now()
+
+	idx.Images[image] = indexedImage{
+		Layers:   layers,
+		LastUsed: now,
+	}
+
+	// Touch the layer files so untracked tools (and older Zarf versions) also see them as recently used
+	for _, layer := range layers {
+		_ = os.Chtimes(layerPath(layer), now, now)
+	}
+
+	return writeIndex(idx)
+}
+
+// MarkRepoUsed marks a cached git repository as just used
+func MarkRepoUsed(repoPath string) {
+	now := time.Now()
+	_ = os.Chtimes(repoPath, now, now)
+}
+
+// MarkComponentUsed marks a cached component import source as just used
+func MarkComponentUsed(componentPath string) {
+	now := time.Now()
+	_ = os.Chtimes(componentPath, now, now)
+}
+
+// List returns every entry in the Zarf cache, oldest first
+func List() ([]Entry, error) {
+	indexLock.Lock()
+	defer indexLock.Unlock()
+
+	return list(readIndex())
+}
+
+// Prune removes cache entries last used before olderThan (if non-zero), then removes the least recently used entries
+// until the cache is at or below maxSize (if non-zero). The removed entries are returned.
+func Prune(olderThan time.Duration, maxSize int64) ([]Entry, error) {
+	message.Debugf("cache.Prune(%s, %d)", olderThan, maxSize)
+
+	indexLock.Lock()
+	defer indexLock.Unlock()
+
+	idx := readIndex()
+	entries, err := list(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	var removed []Entry
+	cutoff := time.Now().Add(-olderThan)
+	for _, entry := range entries {
+		expired := olderThan > 0 && entry.LastUsed.Before(cutoff)
+		oversized := maxSize > 0 && total > maxSize
+		if !expired && !oversized {
+			continue
+		}
+
+		if entry.Kind == KindImage {
+			delete(idx.Images, entry.Name)
+		}
+		for _, path := range entry.paths {
+			if err := os.RemoveAll(path); err != nil {
+				return removed, fmt.Errorf("unable to remove %s from the cache: %w", entry.Name, err)
+			}
+		}
+
+		total -= entry.Size
+		removed = append(removed, entry)
+	}
+
+	return removed, writeIndex(idx)
+}
+
+// list builds the cache entries from the index and the files on disk
+func list(idx index) ([]Entry, error) {
+	var entries []Entry
+
+	layerSizes, err := readLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Count how many images share each layer so a layer is only charged to (and removed with) its last image
+	layerUsers := make(map[string]int)
+	for _, image := range idx.Images {
+		for _, layer := range utils.Unique(image.Layers) {
+			layerUsers[layer]++
+		}
+	}
+
+	claimed := make(map[string]bool)
+	for name, image := range idx.Images {
+		entry := Entry{
+			Kind:     KindImage,
+			Name:     name,
+			LastUsed: image.LastUsed,
+		}
+
+		for _, layer := range utils.Unique(image.Layers) {
+			info, ok := layerSizes[layer]
+			if !ok {
+				continue
+			}
+			claimed[layer] = true
+			if layerUsers[layer] > 1 {
+				continue
+			}
+			entry.Size += info.Size()
+			entry.paths = append(entry.paths, layerPath(layer))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Layers shared between images are tracked on their own so they are only removed once nothing else needs them
+	for layer, info := range layerSizes {
+		if claimed[layer] && layerUsers[layer] < 2 {
+			continue
+		}
+
+		entry := Entry{
+			Kind:     KindLayer,
+			Name:     layer,
+			Size:     info.Size(),
+			LastUsed: info.ModTime(),
+			paths:    []string{layerPath(layer)},
+		}
+
+		// Shared layers are as recent as the most recently used image that needs them
+		for _, image := range idx.Images {
+			for _, imageLayer := range image.Layers {
+				if imageLayer == layer && image.LastUsed.After(entry.LastUsed) {
+					entry.LastUsed = image.LastUsed
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	repos, err := readDirectories(KindRepo, GetRepoCachePath())
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, repos...)
+
+	components, err := readDirectories(KindComponent, GetComponentCachePath())
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, components...)
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+
+	return entries, nil
+}
+
+// readLayers returns the layer files in the image cache keyed by their digest
+func readLayers() (map[string]fs.FileInfo, error) {
+	layers := make(map[string]fs.FileInfo)
+
+	files, err := os.ReadDir(GetImageCachePath())
+	if os.IsNotExist(err) {
+		return layers, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read the image cache: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || file.Name() == indexFileName {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		// Windows caches use '-' instead of ':' in layer file names
+		layers[strings.Replace(file.Name(), "-", ":", 1)] = info
+	}
+
+	return layers, nil
+}
+
+// readDirectories returns an entry of the given kind for every directory in a cache directory (i.e. git repositories)
+func readDirectories(kind, cachePath string) ([]Entry, error) {
+	var entries []Entry
+
+	if utils.InvalidPath(cachePath) {
+		return entries, nil
+	}
+
+	directories, err := utils.ListDirectories(cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, directory := range directories {
+		info, err := os.Stat(directory)
+		if err != nil {
+			continue
+		}
+
+		entry := Entry{
+			Kind:     kind,
+			Name:     filepath.Base(directory),
+			LastUsed: info.ModTime(),
+			paths:    []string{directory},
+		}
+
+		_ = filepath.WalkDir(directory, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if fileInfo, err := d.Info(); err == nil {
+					entry.Size += fileInfo.Size()
+				}
+			}
+			return nil
+		})
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func layerPath(layer string) string {
+	return filepath.Join(GetImageCachePath(), layer)
+}
+
+func readIndex() index {
+	idx := index{}
+	_ = utils.ReadYaml(filepath.Join(GetImageCachePath(), indexFileName), &idx)
+	if idx.Images == nil {
+		idx.Images = make(map[string]indexedImage)
+	}
+	return idx
+}
+
+func writeIndex(idx index) error {
+	if err := utils.CreateDirectory(GetImageCachePath(), 0700); err != nil {
+		return fmt.Errorf("unable to create the image cache directory: %w", err)
+	}
+	return utils.WriteYaml(filepath.Join(GetImageCachePath(), indexFileName), idx, 0600)
+}
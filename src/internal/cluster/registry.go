@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains zarf-specific cluster management functions
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/pkg/k8s"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+)
+
+const (
+	zarfRegistrySelector = "app=docker-registry"
+	zarfRegistryConfig   = "/etc/docker/registry/config.yml"
+)
+
+// RunRegistryGarbageCollection removes blobs no longer referenced by any manifest from the Zarf registry storage.
+// todo: this currently requires kubectl like data injections
+func (c *Cluster) RunRegistryGarbageCollection() error {
+	message.Debug("cluster.RunRegistryGarbageCollection()")
+
+	spinner := message.NewProgressSpinner("Running garbage collection in the Zarf registry")
+	defer spinner.Stop()
+
+	pods := c.Kube.WaitForPodsAndContainers(k8s.PodLookup{
+		Namespace: ZarfNamespace,
+		Selector:  zarfRegistrySelector,
+	}, nil)
+	if len(pods) < 1 {
+		return fmt.Errorf("unable to find a running zarf registry pod")
+	}
+
+	// Every registry pod shares the same volume, so only collect once
+	gcExec := fmt.Sprintf("kubectl exec -n %s %s -- /bin/registry garbage-collect --delete-untagged %s", ZarfNamespace, pods[0], zarfRegistryConfig)
+	stdOut, stdErr, err := utils.ExecCommandWithContext(context.TODO(), false, "sh", "-c", gcExec)
+	spinner.Debugf("%s\n%s", stdOut, stdErr)
+	if err != nil {
+		return fmt.Errorf("unable to garbage collect the registry: %s", stdErr)
+	}
+
+	spinner.Success()
+	return nil
+}
@@ -65,3 +65,32 @@ func (c *Cluster) GenerateRegistryPullCreds(namespace, name string) (*corev1.Sec
 
 	return secretDockerConfig, nil
 }
+
+// UpdateZarfManagedImageSecrets regenerates the existing Zarf image pull secrets so they match the current Zarf state.
+func (c *Cluster) UpdateZarfManagedImageSecrets() error {
+	message.Debug("k8s.UpdateZarfManagedImageSecrets()")
+
+	namespaces, err := c.Kube.GetNamespaces()
+	if err != nil {
+		return fmt.Errorf("unable to get k8s namespaces: %w", err)
+	}
+
+	for _, namespace := range namespaces.Items {
+		// Only update namespaces that Zarf has already given a pull secret
+		currentSecret, err := c.Kube.GetSecret(namespace.Name, config.ZarfImagePullSecretName)
+		if err != nil || currentSecret.Name != config.ZarfImagePullSecretName {
+			continue
+		}
+
+		validSecret, err := c.GenerateRegistryPullCreds(namespace.Name, config.ZarfImagePullSecretName)
+		if err != nil {
+			return fmt.Errorf("unable to generate the registry pull secret for namespace %s: %w", namespace.Name, err)
+		}
+
+		if err := c.Kube.ReplaceSecret(validSecret); err != nil {
+			return fmt.Errorf("unable to update the registry pull secret for namespace %s: %w", namespace.Name, err)
+		}
+	}
+
+	return nil
+}
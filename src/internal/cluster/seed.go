@@ -187,6 +187,14 @@ func (c *Cluster) fillInEmptyGitServerValues(gitServer types.GitServerInfo) type
 		gitServer.InternalServer = true
 	}
 
+	// Zarf's own git server (and any state from before providers existed) is Gitea
+	if gitServer.InternalServer || gitServer.Provider == "" {
+		gitServer.Provider = config.ZarfGitProviderGitea
+	}
+	if gitServer.RepoNaming == "" {
+		gitServer.RepoNaming = config.ZarfGitRepoNamingHash
+	}
+
 	// Generate a push-user password if not provided by init flag
 	if gitServer.PushPassword == "" {
 		gitServer.PushPassword = utils.RandomString(config.ZarfGeneratedPasswordLen)
@@ -81,3 +81,28 @@ func (c *Cluster) SaveZarfState(state types.ZarfState) error {
 
 	return nil
 }
+
+// UpdateRegistryInfo points the Zarf state at a new registry and refreshes the image pull secrets that depend on it
+func (c *Cluster) UpdateRegistryInfo(registryInfo types.RegistryInfo) error {
+	message.Debugf("k8s.UpdateRegistryInfo(%s)", registryInfo.Address)
+
+	state, err := c.LoadZarfState()
+	if err != nil {
+		return fmt.Errorf("unable to load the zarf state: %w", err)
+	}
+	if state.Distro == "" {
+		// If no distro the zarf secret did not load properly
+		return fmt.Errorf("unable to load the zarf state, is this cluster initialized?")
+	}
+
+	// The credentials are used as given, generating them like init does would write ones the registry doesn't accept
+	registryInfo.InternalRegistry = false
+	registryInfo.NodePort = 0
+	state.RegistryInfo = registryInfo
+
+	if err := c.SaveZarfState(state); err != nil {
+		return err
+	}
+
+	return c.UpdateZarfManagedImageSecrets()
+}
@@ -45,6 +45,17 @@ func (c *Cluster) GetDeployedZarfPackages() ([]types.DeployedPackage, error) {
 	return deployedPackages, nil
 }
 
+// GetDeployedPackage gets the metadata information about the package name provided (if it exists in the cluster).
+func (c *Cluster) GetDeployedPackage(packageName string) (deployedPackage types.DeployedPackage, err error) {
+	secret, err := c.Kube.GetSecret("zarf", fmt.Sprintf("zarf-package-%s", packageName))
+	if err != nil {
+		return deployedPackage, err
+	}
+
+	err = json.Unmarshal(secret.Data["data"], &deployedPackage)
+	return deployedPackage, err
+}
+
 // StripZarfLabelsAndSecretsFromNamespaces removes metadata and secrets from existing namespaces no longer manged by Zarf.
 func (c *Cluster) StripZarfLabelsAndSecretsFromNamespaces() {
 	spinner := message.NewProgressSpinner("Removing zarf metadata & secrets from existing namespaces not managed by Zarf")
@@ -91,6 +102,14 @@ func (c *Cluster) RecordPackageDeployment(pkg types.ZarfPackage, components []ty
 	deployedPackageSecret := c.Kube.GenerateSecret("zarf", secretName, corev1.SecretTypeOpaque)
 	deployedPackageSecret.Labels["package-deploy-info"] = packageName
 
+	// Never record the defaults of sensitive variables in the cluster
+	pkg.Variables = append([]types.ZarfPackageVariable{}, pkg.Variables...)
+	for idx, variable := range pkg.Variables {
+		if variable.IsSensitive() {
+			pkg.Variables[idx].Default = ""
+		}
+	}
+
 	stateData, _ := json.Marshal(types.DeployedPackage{
 		Name:               packageName,
 		CLIVersion:         config.CLIVersion,
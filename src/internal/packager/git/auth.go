@@ -9,29 +9,121 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 )
 
+const (
+	// sshKeyEnv is the path to a private key used for ssh:// and git@ repos (otherwise ssh-agent and ~/.ssh keys are tried)
+	sshKeyEnv = "ZARF_GIT_SSH_KEY"
+	// sshKeyPassphraseEnv is the passphrase for the private key in ZARF_GIT_SSH_KEY
+	sshKeyPassphraseEnv = "ZARF_GIT_SSH_KEY_PASSPHRASE"
+	// tokenEnvPrefix prefixes the per-host access token variables, i.e. ZARF_GIT_TOKEN_GITLAB_EXAMPLE_COM for gitlab.example.com
+	tokenEnvPrefix = "ZARF_GIT_TOKEN_"
+	// tokenUsernameEnvPrefix prefixes the per-host username sent with the access token, i.e. ZARF_GIT_USERNAME_GITHUB_COM=x-access-token
+	tokenUsernameEnvPrefix = "ZARF_GIT_USERNAME_"
+	// tokenDefaultUsername is sent with an access token when no username is set for its host, git servers expect
+	// tokens as the password of basic auth and GitLab requires this username for OAuth tokens (GitHub accepts any)
+	tokenDefaultUsername = "oauth2"
+)
+
+var tokenEnvRegex = regexp.MustCompile(`[^A-Z0-9]`)
+
+// FindAuthForHost returns the credentials to use for a git URL, in order of precedence:
+//   - ssh:// (and git@host:path) URLs: ZARF_GIT_SSH_KEY, then ssh-agent, then the default keys in ~/.ssh
+//   - http(s):// URLs: a ZARF_GIT_TOKEN_<HOST> access token (with ZARF_GIT_USERNAME_<HOST> or oauth2 as the username), then ~/.git-credentials, then ~/.netrc
 func (g *Git) FindAuthForHost(baseUrl string) Credential {
-	// Read the ~/.git-credentials file
-	gitCreds := g.credentialParser()
+	gitURL, err := url.Parse(normalizeURL(baseUrl))
+	if err != nil || gitURL.Host == "" {
+		message.Debugf("Unable to parse %s to look up git credentials", baseUrl)
+		return Credential{}
+	}
 
-	// Will be nil unless a match is found
-	var matchedCred Credential
+	if gitURL.Scheme == "ssh" {
+		return g.findSSHAuth(gitURL)
+	}
+
+	if token := os.Getenv(tokenEnvName(tokenEnvPrefix, gitURL.Hostname())); token != "" {
+		username := os.Getenv(tokenEnvName(tokenUsernameEnvPrefix, gitURL.Hostname()))
+		if username == "" {
+			username = tokenDefaultUsername
+		}
+		return Credential{
+			Path: gitURL.Host,
+			Auth: &http.BasicAuth{
+				Username: username,
+				Password: token,
+			},
+		}
+	}
 
 	// Look for a match for the given host path in the creds file
-	for _, gitCred := range gitCreds {
-		hasPath := strings.Contains(baseUrl, gitCred.Path)
-		if hasPath {
-			matchedCred = gitCred
-			break
+	for _, gitCred := range g.credentialParser() {
+		if strings.Contains(baseUrl, gitCred.Path) {
+			return gitCred
+		}
+	}
+
+	for _, netrcCred := range g.netrcParser() {
+		if netrcCred.Path == gitURL.Hostname() || netrcCred.Path == "" {
+			return netrcCred
 		}
 	}
 
-	return matchedCred
+	// Will be nil unless a match is found
+	return Credential{}
+}
+
+// findSSHAuth returns ssh key auth for a repo, the host key is verified against ~/.ssh/known_hosts (or $SSH_KNOWN_HOSTS)
+func (g *Git) findSSHAuth(gitURL *url.URL) Credential {
+	user := gitURL.User.Username()
+	if user == "" {
+		user = ssh.DefaultUsername
+	}
+
+	credential := Credential{Path: gitURL.Host}
+
+	if keyPath := os.Getenv(sshKeyEnv); keyPath != "" {
+		auth, err := ssh.NewPublicKeysFromFile(user, keyPath, os.Getenv(sshKeyPassphraseEnv))
+		if err != nil {
+			message.Warnf("Unable to load the ssh key from %s=%s: %s", sshKeyEnv, keyPath, err.Error())
+			return credential
+		}
+		credential.Auth = auth
+		return credential
+	}
+
+	if os.Getenv("SSH_AUTH_SOCK") != "" {
+		auth, err := ssh.NewSSHAgentAuth(user)
+		if err == nil {
+			credential.Auth = auth
+			return credential
+		}
+		message.Debugf("Unable to connect to the ssh-agent: %s", err.Error())
+	}
+
+	homePath, _ := os.UserHomeDir()
+	for _, keyName := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		keyPath := filepath.Join(homePath, ".ssh", keyName)
+		if _, err := os.Stat(keyPath); err != nil {
+			continue
+		}
+
+		// Default keys protected by a passphrase need ssh-agent or ZARF_GIT_SSH_KEY_PASSPHRASE
+		auth, err := ssh.NewPublicKeysFromFile(user, keyPath, os.Getenv(sshKeyPassphraseEnv))
+		if err == nil {
+			credential.Auth = auth
+			return credential
+		}
+		message.Debugf("Unable to load the ssh key %s: %s", keyPath, err.Error())
+	}
+
+	return credential
 }
 
 func (g *Git) credentialParser() []Credential {
@@ -44,7 +136,7 @@ func (g *Git) credentialParser() []Credential {
 	defer func(credentialsFile *os.File) {
 		err := credentialsFile.Close()
 		if err != nil {
-			message.Debugf("Unable to load an existing git credentials file: %w", err)
+			message.Debugf("Unable to load an existing git credentials file: %s", err)
 		}
 	}(credentialsFile)
 
@@ -57,7 +149,7 @@ func (g *Git) credentialParser() []Credential {
 		password, _ := gitUrl.User.Password()
 		credential := Credential{
 			Path: gitUrl.Host,
-			Auth: http.BasicAuth{
+			Auth: &http.BasicAuth{
 				Username: gitUrl.User.Username(),
 				Password: password,
 			},
@@ -67,3 +159,80 @@ func (g *Git) credentialParser() []Credential {
 
 	return credentials
 }
+
+// netrcParser reads the machine (and default) entries from $NETRC or ~/.netrc, the default entry has an empty Path
+func (g *Git) netrcParser() []Credential {
+	var credentials []Credential
+
+	netrcPath := os.Getenv("NETRC")
+	if netrcPath == "" {
+		homePath, _ := os.UserHomeDir()
+		netrcName := ".netrc"
+		if runtime.GOOS == "windows" {
+			netrcName = "_netrc"
+		}
+		netrcPath = filepath.Join(homePath, netrcName)
+	}
+
+	data, err := os.ReadFile(netrcPath)
+	if err != nil {
+		return credentials
+	}
+
+	var current *Credential
+	var auth *http.BasicAuth
+	fields := strings.Fields(string(data))
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine", "default":
+			if current != nil {
+				credentials = append(credentials, *current)
+			}
+			auth = &http.BasicAuth{}
+			current = &Credential{Auth: auth}
+			if fields[i] == "machine" && i+1 < len(fields) {
+				i++
+				current.Path = fields[i]
+			}
+		case "login", "password", "account":
+			if current == nil || i+1 >= len(fields) {
+				continue
+			}
+			i++
+			if fields[i-1] == "login" {
+				auth.Username = fields[i]
+			} else if fields[i-1] == "password" {
+				auth.Password = fields[i]
+			}
+		case "macdef":
+			// Macro bodies are free text that git never uses, skip them up to the next entry rather than misread them
+			for i+1 < len(fields) && fields[i+1] != "machine" && fields[i+1] != "default" {
+				i++
+			}
+		}
+	}
+
+	if current != nil {
+		credentials = append(credentials, *current)
+	}
+
+	// Machine entries take precedence over the default entry regardless of their order in the file
+	var ordered []Credential
+	for _, credential := range credentials {
+		if credential.Path != "" {
+			ordered = append(ordered, credential)
+		}
+	}
+	for _, credential := range credentials {
+		if credential.Path == "" {
+			ordered = append(ordered, credential)
+		}
+	}
+
+	return ordered
+}
+
+// tokenEnvName returns the environment variable with the given prefix for a host
+func tokenEnvName(prefix string, host string) string {
+	return prefix + tokenEnvRegex.ReplaceAllString(strings.ToUpper(host), "_")
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package git contains functions for interacting with git repositories
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/go-git/go-git/v5"
+	goConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// bundleHeader is the signature of a v2 git bundle, see https://git-scm.com/docs/gitformat-bundle
+const bundleHeader = "# v2 git bundle\n"
+
+// MirrorRepoName returns the name of a repo in the git server from either its name (optionally prefixed with its group) or its upstream URL.
+func (g *Git) MirrorRepoName(repo string) (string, error) {
+	if strings.Contains(repo, "://") || scpURLRegex.MatchString(repo) {
+		return g.mirrorRepoName(repo)
+	}
+	return strings.TrimPrefix(repo, g.group()+"/"), nil
+}
+
+// ExportBundle writes a git bundle of the branches and tags of a repo in the git server that changed since they were pushed.
+// The basis is the list of commits Zarf pushed, which the bundle expects the upstream repo to already have.
+// It returns the refs included in the bundle, no bundle is written if nothing changed.
+func (g *Git) ExportBundle(repoName string, basis []string, bundlePath string) ([]string, error) {
+	message.Debugf("git.ExportBundle(%s, %#v, %s)", repoName, basis, bundlePath)
+
+	tmpPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tmpdir: %w", err)
+	}
+	defer os.RemoveAll(tmpPath)
+
+	repo, err := git.PlainInit(tmpPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the git repo: %w", err)
+	}
+
+	_, err = repo.CreateRemote(&goConfig.RemoteConfig{
+		Name: offlineRemoteName,
+		URLs: []string{g.provider().repoURL(repoName)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create offline remote: %w", err)
+	}
+
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: offlineRemoteName,
+		RefSpecs: []goConfig.RefSpec{
+			"+refs/heads/*:refs/heads/*",
+			"+refs/tags/*:refs/tags/*",
+		},
+		Tags: git.NoTags,
+		Auth: &http.BasicAuth{
+			Username: g.Server.PushUsername,
+			Password: g.Server.PushPassword,
+		},
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("unable to fetch %s from the git server: %w", repoName, err)
+	}
+
+	// Only the commits Zarf pushed that the git server still has can be left out of the bundle
+	basisHashes := make(map[plumbing.Hash]bool)
+	for _, hash := range basis {
+		h := plumbing.NewHash(hash)
+		if _, err := repo.Storer.EncodedObject(plumbing.AnyObject, h); err == nil {
+			basisHashes[h] = true
+		}
+	}
+
+	references, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify references when getting the repo's references: %w", err)
+	}
+
+	var changedRefs []*plumbing.Reference
+	_ = references.ForEach(func(ref *plumbing.Reference) error {
+		if (ref.Name().IsBranch() || ref.Name().IsTag()) && ref.Type() == plumbing.HashReference && !basisHashes[ref.Hash()] {
+			changedRefs = append(changedRefs, ref)
+		}
+		return nil
+	})
+
+	if len(changedRefs) == 0 {
+		return nil, nil
+	}
+	sort.Slice(changedRefs, func(i, j int) bool {
+		return changedRefs[i].Name() < changedRefs[j].Name()
+	})
+
+	var include, ignore []plumbing.Hash
+	for _, ref := range changedRefs {
+		include = append(include, ref.Hash())
+	}
+	for hash := range basisHashes {
+		ignore = append(ignore, hash)
+	}
+
+	objects, err := revlist.Objects(repo.Storer, include, ignore)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the new git objects: %w", err)
+	}
+
+	bundleFile, err := os.Create(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s: %w", bundlePath, err)
+	}
+	defer bundleFile.Close()
+
+	writer := bufio.NewWriter(bundleFile)
+	_, _ = writer.WriteString(bundleHeader)
+
+	// The upstream repo must already have the commits the bundle builds on
+	prerequisites := make(map[plumbing.Hash]bool)
+	for _, hash := range ignore {
+		if commit := peelToCommit(repo, hash); commit != nil && !prerequisites[commit.Hash] {
+			prerequisites[commit.Hash] = true
+			fmt.Fprintf(writer, "-%s %s\n", commit.Hash, strings.SplitN(commit.Message, "\n", 2)[0])
+		}
+	}
+
+	var exported []string
+	for _, ref := range changedRefs {
+		fmt.Fprintf(writer, "%s %s\n", ref.Hash(), ref.Name())
+		exported = append(exported, ref.Name().String())
+	}
+	_, _ = writer.WriteString("\n")
+
+	if _, err := packfile.NewEncoder(writer, repo.Storer, false).Encode(objects, 10); err != nil {
+		return nil, fmt.Errorf("unable to write the bundle pack: %w", err)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, fmt.Errorf("unable to write %s: %w", bundlePath, err)
+	}
+
+	return exported, nil
+}
+
+// peelToCommit returns the commit an object (a commit or annotated tag) points to
+func peelToCommit(repo *git.Repository, hash plumbing.Hash) *object.Commit {
+	if commit, err := repo.CommitObject(hash); err == nil {
+		return commit
+	}
+	if tag, err := repo.TagObject(hash); err == nil {
+		if commit, err := tag.Commit(); err == nil {
+			return commit
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@
 package git
 
 import (
+	"fmt"
+
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -21,6 +23,21 @@ func (g *Git) CheckoutTag(tag string) {
 	g.checkout(options)
 }
 
+// HeadCommit returns the hash of the commit currently checked out
+func (g *Git) HeadCommit() (string, error) {
+	repo, err := git.PlainOpen(g.GitPath)
+	if err != nil {
+		return "", fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("unable to identify the repo head: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
 // checkoutTagAsBranch performs a `git checkout` of the provided tag but rather
 // than checking out to a detached head, checks out to the provided branch ref
 // It will delete the branch provided if it exists
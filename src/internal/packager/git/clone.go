@@ -7,6 +7,7 @@ package git
 import (
 	"context"
 	"errors"
+	"os/exec"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
@@ -28,8 +29,8 @@ func (g *Git) clone(gitDirectory string, gitURL string, onlyFetchRef bool) (*git
 	gitCred := g.FindAuthForHost(gitURL)
 
 	// Gracefully handle no git creds on the system (like our CI/CD)
-	if gitCred.Auth.Username != "" {
-		cloneOptions.Auth = &gitCred.Auth
+	if gitCred.Auth != nil {
+		cloneOptions.Auth = gitCred.Auth
 	}
 
 	// Clone the given repo
@@ -45,6 +46,12 @@ func (g *Git) clone(gitDirectory string, gitURL string, onlyFetchRef bool) (*git
 		return repo, git.ErrRepositoryAlreadyExists
 	} else if err != nil {
 		g.Spinner.Debugf("Failed to clone repo: %s", err)
+
+		// Without a host git there is nothing to fall back to
+		if _, lookErr := exec.LookPath("git"); lookErr != nil {
+			return nil, err
+		}
+
 		message.Infof("Falling back to host git for %s", gitURL)
 
 		// If we can't clone with go-git, fallback to the host clone
@@ -7,7 +7,7 @@ package git
 import (
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/types"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 )
 
 type Git struct {
@@ -21,7 +21,7 @@ type Git struct {
 
 type Credential struct {
 	Path string
-	Auth http.BasicAuth
+	Auth transport.AuthMethod
 }
 
 const onlineRemoteName = "online-upstream"
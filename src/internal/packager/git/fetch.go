@@ -7,6 +7,7 @@ package git
 import (
 	"context"
 	"errors"
+	"os/exec"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
@@ -66,8 +67,8 @@ func (g *Git) fetch(refspecs ...goConfig.RefSpec) error {
 		RefSpecs:   refspecs,
 	}
 
-	if gitCred.Auth.Username != "" {
-		fetchOptions.Auth = &gitCred.Auth
+	if gitCred.Auth != nil {
+		fetchOptions.Auth = gitCred.Auth
 	}
 
 	err = repo.Fetch(fetchOptions)
@@ -76,6 +77,12 @@ func (g *Git) fetch(refspecs ...goConfig.RefSpec) error {
 		message.Debug("Already fetched requested ref")
 	} else if err != nil {
 		message.Debugf("Failed to fetch repo: %s", err)
+
+		// Without a host git there is nothing to fall back to
+		if _, lookErr := exec.LookPath("git"); lookErr != nil {
+			return err
+		}
+
 		message.Infof("Falling back to host git for %s", gitURL)
 
 		// If we can't fetch with go-git, fallback to the host fetch
@@ -17,8 +17,93 @@ import (
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 )
 
-// CreateReadOnlyUser uses the Gitea API to create a non-admin zarf user
-func (g *Git) CreateReadOnlyUser() error {
+// gitea is the Gitea server deployed by the Zarf init package (or an external Gitea).
+type gitea struct {
+	*Git
+}
+
+func (g gitea) repoURL(repoName string) string {
+	return fmt.Sprintf("%s/%s/%s", g.Server.Address, g.group(), repoName)
+}
+
+// createRepo relies on Gitea's push-to-create
+func (g gitea) createRepo(_ string) error {
+	return nil
+}
+
+// grantReadAccess adds the read-only user Zarf created on its own Gitea to the repo
+func (g gitea) grantReadAccess(repoName string) error {
+	if !g.Server.InternalServer {
+		return nil
+	}
+	return g.addReadOnlyUserToRepo(g.Server.Address, repoName)
+}
+
+// repoSource reads the upstream URL from the repo description
+func (g gitea) repoSource(repoName string) (string, error) {
+	var repo struct {
+		Description string `json:"description"`
+	}
+	status, err := g.doRequest("GET", fmt.Sprintf("/repos/%s/%s", g.group(), repoName), nil, &repo)
+	if status == netHttp.StatusNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return sourceFromDescription(repo.Description), nil
+}
+
+// setRepoSource records the upstream URL in the repo description
+func (g gitea) setRepoSource(repoName, sourceURL string) error {
+	updateRepoBody := map[string]string{
+		"description": repoDescription(sourceURL),
+	}
+	_, err := g.doRequest("PATCH", fmt.Sprintf("/repos/%s/%s", g.group(), repoName), updateRepoBody, nil)
+	return err
+}
+
+// listRepos lists the repos owned by the user (or organization) Zarf pushes to
+func (g gitea) listRepos() ([]MirroredRepo, error) {
+	var repos []MirroredRepo
+	for page := 1; ; page++ {
+		var result struct {
+			Data []struct {
+				Name        string `json:"name"`
+				Description string `json:"description"`
+				Owner       struct {
+					Login string `json:"login"`
+				} `json:"owner"`
+			} `json:"data"`
+		}
+		if _, err := g.doRequest("GET", fmt.Sprintf("/repos/search?limit=50&page=%d", page), nil, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Data) == 0 {
+			return repos, nil
+		}
+
+		for _, repo := range result.Data {
+			if repo.Owner.Login != g.group() {
+				continue
+			}
+			repos = append(repos, MirroredRepo{
+				Name:   g.group() + "/" + repo.Name,
+				URL:    g.repoURL(repo.Name),
+				Source: sourceFromDescription(repo.Description),
+			})
+		}
+	}
+}
+
+// doRequest sends a request to the Gitea v1 API as the push-user, decoding the response into out if given
+func (g gitea) doRequest(method, path string, body, out interface{}) (int, error) {
+	return doJSONRequest(method, g.Server.Address+"/api/v1"+path, body, out, func(request *netHttp.Request) {
+		request.SetBasicAuth(g.Server.PushUsername, g.Server.PushPassword)
+	})
+}
+
+// createReadOnlyUser uses the Gitea API to create a non-admin zarf user
+func (g gitea) createReadOnlyUser() error {
 	message.Debugf("git.CreateReadOnlyUser()")
 
 	// Establish a git tunnel to send the repo
@@ -102,7 +187,7 @@ func (g *Git) CreateReadOnlyUser() error {
 	}
 }
 
-func (g *Git) addReadOnlyUserToRepo(tunnelUrl, repo string) error {
+func (g gitea) addReadOnlyUserToRepo(tunnelUrl, repo string) error {
 	message.Debugf("git.addReadOnlyUserToRepo()")
 
 	// Add the readonly user to the repo
@@ -115,7 +200,7 @@ func (g *Git) addReadOnlyUserToRepo(tunnelUrl, repo string) error {
 	}
 
 	// Send API request to add a user as a read-only collaborator to a repo
-	addColabEndpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/collaborators/%s", tunnelUrl, g.Server.PushUsername, repo, g.Server.PullUsername)
+	addColabEndpoint := fmt.Sprintf("%s/api/v1/repos/%s/%s/collaborators/%s", tunnelUrl, g.group(), repo, g.Server.PullUsername)
 	addColabRequest, _ := netHttp.NewRequest("PUT", addColabEndpoint, bytes.NewBuffer(addColabData))
 	out, err := g.DoHttpThings(addColabRequest, g.Server.PushUsername, g.Server.PushPassword)
 	message.Debugf("PUT %s:\n%s", addColabEndpoint, string(out))
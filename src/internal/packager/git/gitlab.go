@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package git contains functions for interacting with git repositories
+package git
+
+import (
+	"fmt"
+	"net/url"
+
+	netHttp "net/http"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+)
+
+// gitlabReporterAccess is the GitLab access level that can clone but not push
+const gitlabReporterAccess = 20
+
+// gitlab is an existing GitLab server, the push-password must be a personal (or group) access token with the api scope.
+type gitlab struct {
+	*Git
+}
+
+func (g gitlab) repoURL(repoName string) string {
+	return fmt.Sprintf("%s/%s/%s.git", g.Server.Address, g.group(), repoName)
+}
+
+// createRepo creates a private project for the repo in the configured group if it does not exist yet
+func (g gitlab) createRepo(repoName string) error {
+	message.Debugf("git.gitlab.createRepo(%s)", repoName)
+
+	projectPath := url.PathEscape(g.group() + "/" + repoName)
+	status, err := g.doRequest("GET", "/projects/"+projectPath, nil, nil)
+	if status == netHttp.StatusOK {
+		return nil
+	} else if status != netHttp.StatusNotFound {
+		return err
+	}
+
+	var namespace struct {
+		ID int `json:"id"`
+	}
+	if _, err := g.doRequest("GET", "/namespaces/"+url.PathEscape(g.group()), nil, &namespace); err != nil {
+		return fmt.Errorf("unable to find the GitLab group %s: %w", g.group(), err)
+	}
+
+	createProjectBody := map[string]interface{}{
+		"name":         repoName,
+		"path":         repoName,
+		"namespace_id": namespace.ID,
+		"visibility":   "private",
+	}
+	if _, err := g.doRequest("POST", "/projects", createProjectBody, nil); err != nil {
+		return fmt.Errorf("unable to create the GitLab project %s: %w", repoName, err)
+	}
+
+	return nil
+}
+
+// grantReadAccess adds the pull-user to the project as a reporter
+func (g gitlab) grantReadAccess(repoName string) error {
+	message.Debugf("git.gitlab.grantReadAccess(%s)", repoName)
+
+	// The push-user already has access, and may be the only user Zarf was given
+	if g.Server.PullUsername == "" || g.Server.PullUsername == g.Server.PushUsername {
+		return nil
+	}
+
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if _, err := g.doRequest("GET", "/users?username="+url.QueryEscape(g.Server.PullUsername), nil, &users); err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return fmt.Errorf("the GitLab user %s does not exist", g.Server.PullUsername)
+	}
+
+	addMemberBody := map[string]interface{}{
+		"user_id":      users[0].ID,
+		"access_level": gitlabReporterAccess,
+	}
+	projectPath := url.PathEscape(g.group() + "/" + repoName)
+	status, err := g.doRequest("POST", "/projects/"+projectPath+"/members", addMemberBody, nil)
+
+	// A conflict means the user is already a member (possibly inherited from the group)
+	if status == netHttp.StatusConflict {
+		return nil
+	}
+	return err
+}
+
+// createReadOnlyUser is not supported as Zarf does not administer GitLab users, the pull-user must already exist
+func (g gitlab) createReadOnlyUser() error {
+	return fmt.Errorf("the %s git provider does not support creating users, provide an existing pull-user instead", config.ZarfGitProviderGitLab)
+}
+
+// repoSource reads the upstream URL from the project description
+func (g gitlab) repoSource(repoName string) (string, error) {
+	var project struct {
+		Description string `json:"description"`
+	}
+	status, err := g.doRequest("GET", "/projects/"+url.PathEscape(g.group()+"/"+repoName), nil, &project)
+	if status == netHttp.StatusNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return sourceFromDescription(project.Description), nil
+}
+
+// setRepoSource records the upstream URL in the project description
+func (g gitlab) setRepoSource(repoName, sourceURL string) error {
+	updateProjectBody := map[string]string{
+		"description": repoDescription(sourceURL),
+	}
+	_, err := g.doRequest("PUT", "/projects/"+url.PathEscape(g.group()+"/"+repoName), updateProjectBody, nil)
+	return err
+}
+
+// listRepos lists the projects in the group (or user namespace) Zarf pushes to
+func (g gitlab) listRepos() ([]MirroredRepo, error) {
+	var namespace struct {
+		Kind string `json:"kind"`
+	}
+	if _, err := g.doRequest("GET", "/namespaces/"+url.PathEscape(g.group()), nil, &namespace); err != nil {
+		return nil, fmt.Errorf("unable to find the GitLab group %s: %w", g.group(), err)
+	}
+
+	kind := "groups"
+	if namespace.Kind == "user" {
+		kind = "users"
+	}
+
+	var repos []MirroredRepo
+	for page := 1; ; page++ {
+		var projects []struct {
+			Path        string `json:"path"`
+			Description string `json:"description"`
+		}
+		endpoint := fmt.Sprintf("/%s/%s/projects?per_page=100&page=%d", kind, url.PathEscape(g.group()), page)
+		if _, err := g.doRequest("GET", endpoint, nil, &projects); err != nil {
+			return nil, err
+		}
+		if len(projects) == 0 {
+			return repos, nil
+		}
+
+		for _, project := range projects {
+			repos = append(repos, MirroredRepo{
+				Name:   g.group() + "/" + project.Path,
+				URL:    g.repoURL(project.Path),
+				Source: sourceFromDescription(project.Description),
+			})
+		}
+	}
+}
+
+// doRequest sends a request to the GitLab v4 API, decoding the response into out if given
+func (g gitlab) doRequest(method, path string, body, out interface{}) (int, error) {
+	return doJSONRequest(method, g.Server.Address+"/api/v4"+path, body, out, func(request *netHttp.Request) {
+		request.Header.Add("PRIVATE-TOKEN", g.Server.PushPassword)
+	})
+}
@@ -0,0 +1,561 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package git contains functions for interacting with git repositories
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	netHttp "net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitSSH "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	lfsMediaType = "application/vnd.git-lfs+json"
+	// Pointer files are always under 1024 bytes, see https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+	lfsPointerMaxSize = 1024
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	lfsBatchSize      = 100
+)
+
+var (
+	lfsOidRegex  = regexp.MustCompile(`(?m)^oid sha256:([0-9a-f]{64})$`)
+	lfsSizeRegex = regexp.MustCompile(`(?m)^size (\d+)$`)
+
+	lfsOidNameRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+type lfsPointer struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	lfsPointer
+	Actions map[string]lfsAction `json:"actions"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// lfsAction is an href (and headers) to transfer an object, also returned by git-lfs-authenticate over ssh
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+// lfsEndpoint is where and how to reach a repo's LFS batch API
+type lfsEndpoint struct {
+	lfsAction
+	auth http.AuthMethod
+	// Rewrite transfer hrefs to this base URL (i.e. when the server is reached through a tunnel)
+	rewriteBase string
+}
+
+// LFSSize returns the number and total size of the LFS objects stored in a git repo
+func LFSSize(gitPath string) (objects int, size int64) {
+	_ = filepath.WalkDir(filepath.Join(gitPath, ".git", "lfs", "objects"), func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && lfsOidNameRegex.MatchString(d.Name()) {
+			if info, err := d.Info(); err == nil {
+				objects++
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return objects, size
+}
+
+// fetchLFS downloads the LFS objects referenced by the tips of every ref in the repo at g.GitPath.
+// Objects already in cachePath (another copy of the repo) are reused and new downloads are added to it.
+func (g *Git) fetchLFS(cachePath string) error {
+	message.Debugf("git.fetchLFS(%s)", cachePath)
+
+	repo, err := git.PlainOpen(g.GitPath)
+	if err != nil {
+		return fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+
+	pointers, err := findLFSPointers(repo)
+	if err != nil {
+		return err
+	}
+
+	var missing []lfsPointer
+	for _, pointer := range pointers {
+		target := lfsObjectPath(g.GitPath, pointer.Oid)
+		if !utils.InvalidPath(target) {
+			continue
+		}
+		if cachePath != "" {
+			if cached := lfsObjectPath(cachePath, pointer.Oid); !utils.InvalidPath(cached) {
+				if err := utils.CreatePathAndCopy(cached, target); err == nil {
+					continue
+				}
+			}
+		}
+		missing = append(missing, pointer)
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	remote, err := repo.Remote(onlineRemoteName)
+	if err != nil {
+		return fmt.Errorf("unable to find the git remote: %w", err)
+	}
+
+	endpoint, err := g.onlineLFSEndpoint(remote.Config().URLs[0], "download")
+	if err != nil {
+		return err
+	}
+
+	downloaded := 0
+	return forEachLFSBatch(endpoint, "download", missing, func(object lfsBatchObject, action lfsAction) error {
+		downloaded++
+		g.Spinner.Updatef("Downloading git LFS objects (%d of %d)", downloaded, len(missing))
+
+		target := lfsObjectPath(g.GitPath, object.Oid)
+		if err := downloadLFSObject(action, object.lfsPointer, target); err != nil {
+			return err
+		}
+		if cachePath != "" {
+			_ = utils.CreatePathAndCopy(target, lfsObjectPath(cachePath, object.Oid))
+		}
+		return nil
+	})
+}
+
+// pushLFS uploads the LFS objects stored in the repo at g.GitPath to the Zarf git server
+func (g *Git) pushLFS(repoName string, spinner *message.Spinner) error {
+	message.Debugf("git.pushLFS(%s)", repoName)
+
+	var objects []lfsPointer
+	_ = filepath.WalkDir(filepath.Join(g.GitPath, ".git", "lfs", "objects"), func(_ string, d fs.DirEntry, err error) error {
+		// Skip anything that is not a complete object (i.e. an interrupted download)
+		if err == nil && !d.IsDir() && lfsOidNameRegex.MatchString(d.Name()) {
+			if info, err := d.Info(); err == nil {
+				objects = append(objects, lfsPointer{Oid: d.Name(), Size: info.Size()})
+			}
+		}
+		return nil
+	})
+
+	if len(objects) == 0 {
+		return nil
+	}
+
+	spinner.Updatef("Pushing %d git LFS objects", len(objects))
+
+	endpoint := lfsEndpoint{
+		lfsAction: lfsAction{
+			Href: g.lfsURL(repoName),
+		},
+		auth: &http.BasicAuth{
+			Username: g.Server.PushUsername,
+			Password: g.Server.PushPassword,
+		},
+	}
+
+	// The internal server hands out hrefs with its in-cluster URL, send them through our connection instead
+	if g.Server.InternalServer {
+		endpoint.rewriteBase = g.Server.Address
+	}
+
+	return forEachLFSBatch(endpoint, "upload", objects, func(object lfsBatchObject, action lfsAction) error {
+		if err := uploadLFSObject(action, lfsObjectPath(g.GitPath, object.Oid)); err != nil {
+			return err
+		}
+
+		// Let the server know the upload is complete if it asks
+		if verify, ok := object.Actions["verify"]; ok {
+			verify.Href = endpoint.rewrite(verify.Href)
+			body, _ := json.Marshal(object.lfsPointer)
+			request, err := newLFSRequest("POST", verify, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			if _, err := doLFSRequest(request); err != nil {
+				return fmt.Errorf("unable to verify LFS object %s: %w", object.Oid, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// onlineLFSEndpoint returns the LFS batch API of a remote, ssh remotes are resolved with git-lfs-authenticate
+func (g *Git) onlineLFSEndpoint(remoteURL, operation string) (lfsEndpoint, error) {
+	endpoint := lfsEndpoint{}
+
+	parsed, err := url.Parse(normalizeURL(remoteURL))
+	if err != nil {
+		return endpoint, fmt.Errorf("unable to parse the git remote %s: %w", remoteURL, err)
+	}
+
+	gitCred := g.FindAuthForHost(remoteURL)
+
+	if parsed.Scheme == "ssh" {
+		sshAuth, ok := gitCred.Auth.(gitSSH.AuthMethod)
+		if !ok {
+			return endpoint, fmt.Errorf("no ssh credentials found to fetch the LFS objects of %s", remoteURL)
+		}
+		endpoint.lfsAction, err = sshLFSAuthenticate(parsed, sshAuth, operation)
+		return endpoint, err
+	}
+
+	repoPath := strings.TrimSuffix(parsed.Path, "/")
+	if !strings.HasSuffix(repoPath, ".git") {
+		repoPath += ".git"
+	}
+	parsed.Path = repoPath + "/info/lfs"
+	parsed.User = nil
+	endpoint.Href = parsed.String()
+
+	if httpAuth, ok := gitCred.Auth.(http.AuthMethod); ok {
+		endpoint.auth = httpAuth
+	}
+
+	return endpoint, nil
+}
+
+// sshLFSAuthenticate runs git-lfs-authenticate on the ssh server to get the LFS href and a temporary token
+func sshLFSAuthenticate(remote *url.URL, auth gitSSH.AuthMethod, operation string) (lfsAction, error) {
+	var action lfsAction
+
+	clientConfig, err := auth.ClientConfig()
+	if err != nil {
+		return action, err
+	}
+	clientConfig.Timeout = 30 * time.Second
+
+	port := remote.Port()
+	if port == "" {
+		port = "22"
+	}
+
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", remote.Hostname(), port), clientConfig)
+	if err != nil {
+		return action, fmt.Errorf("unable to connect to %s over ssh: %w", remote.Host, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return action, err
+	}
+	defer session.Close()
+
+	output, err := session.Output(fmt.Sprintf("git-lfs-authenticate %s %s", strings.TrimPrefix(remote.Path, "/"), operation))
+	if err != nil {
+		return action, fmt.Errorf("git-lfs-authenticate failed on %s: %w", remote.Host, err)
+	}
+
+	return action, json.Unmarshal(output, &action)
+}
+
+// forEachLFSBatch requests transfer actions in batches and calls transfer for every object the server wants transferred
+func forEachLFSBatch(endpoint lfsEndpoint, operation string, objects []lfsPointer, transfer func(lfsBatchObject, lfsAction) error) error {
+	for start := 0; start < len(objects); start += lfsBatchSize {
+		end := start + lfsBatchSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+
+		body, err := json.Marshal(lfsBatchRequest{
+			Operation: operation,
+			Transfers: []string{"basic"},
+			Objects:   objects[start:end],
+		})
+		if err != nil {
+			return err
+		}
+
+		batchAction := endpoint.lfsAction
+		batchAction.Href = strings.TrimSuffix(endpoint.Href, "/") + "/objects/batch"
+		request, err := newLFSRequest("POST", batchAction, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		if endpoint.auth != nil && len(endpoint.Header) == 0 {
+			endpoint.auth.SetAuth(request)
+		}
+
+		responseBody, err := doLFSRequest(request)
+		if err != nil {
+			return fmt.Errorf("LFS batch %s request failed: %w", operation, err)
+		}
+
+		var response lfsBatchResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return fmt.Errorf("unable to read the LFS batch response: %w", err)
+		}
+
+		for _, object := range response.Objects {
+			if object.Error != nil {
+				return fmt.Errorf("LFS object %s: %s (%d)", object.Oid, object.Error.Message, object.Error.Code)
+			}
+
+			// No action means the server already has (or does not need) this object
+			action, ok := object.Actions[operation]
+			if !ok {
+				continue
+			}
+			action.Href = endpoint.rewrite(action.Href)
+
+			if err := transfer(object, action); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rewrite points an href at the rewrite base, keeping its path and query
+func (e lfsEndpoint) rewrite(href string) string {
+	if e.rewriteBase == "" {
+		return href
+	}
+
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	base, err := url.Parse(e.rewriteBase)
+	if err != nil {
+		return href
+	}
+
+	parsed.Scheme = base.Scheme
+	parsed.Host = base.Host
+	return parsed.String()
+}
+
+func downloadLFSObject(action lfsAction, pointer lfsPointer, target string) error {
+	request, err := newLFSRequest("GET", action, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/octet-stream")
+
+	response, err := lfsClient().Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to download LFS object %s: %w", pointer.Oid, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unable to download LFS object %s: got status code of %d", pointer.Oid, response.StatusCode)
+	}
+
+	if err := utils.CreateFilePath(target); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so an interrupted download never looks like a valid object
+	tmpTarget := target + ".tmp"
+	file, err := os.Create(tmpTarget)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), response.Body)
+	_ = file.Close()
+	if err != nil {
+		_ = os.Remove(tmpTarget)
+		return fmt.Errorf("unable to download LFS object %s: %w", pointer.Oid, err)
+	}
+
+	if written != pointer.Size || hex.EncodeToString(hash.Sum(nil)) != pointer.Oid {
+		_ = os.Remove(tmpTarget)
+		return fmt.Errorf("LFS object %s failed verification", pointer.Oid)
+	}
+
+	return os.Rename(tmpTarget, target)
+}
+
+func uploadLFSObject(action lfsAction, source string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	request, err := newLFSRequest("PUT", action, file)
+	if err != nil {
+		return err
+	}
+	request.ContentLength = info.Size()
+	request.Header.Set("Content-Type", "application/octet-stream")
+
+	if _, err := doLFSRequest(request); err != nil {
+		return fmt.Errorf("unable to upload LFS object %s: %w", filepath.Base(source), err)
+	}
+
+	return nil
+}
+
+func newLFSRequest(method string, action lfsAction, body io.Reader) (*netHttp.Request, error) {
+	request, err := netHttp.NewRequest(method, action.Href, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", lfsMediaType)
+	if body != nil {
+		request.Header.Set("Content-Type", lfsMediaType)
+	}
+	for key, value := range action.Header {
+		request.Header.Set(key, value)
+	}
+
+	return request, nil
+}
+
+func doLFSRequest(request *netHttp.Request) ([]byte, error) {
+	response, err := lfsClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("got status code of %d during http request with body of: %s", response.StatusCode, string(responseBody))
+	}
+
+	return responseBody, nil
+}
+
+func lfsClient() *netHttp.Client {
+	// Objects can be large, only time out if the connection stalls
+	return &netHttp.Client{
+		Transport: &netHttp.Transport{
+			Proxy:                 netHttp.ProxyFromEnvironment,
+			ResponseHeaderTimeout: 60 * time.Second,
+		},
+	}
+}
+
+// findLFSPointers returns the unique LFS pointers in the trees of every ref's tip commit
+func findLFSPointers(repo *git.Repository) ([]lfsPointer, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the repo references: %w", err)
+	}
+
+	seenCommits := make(map[plumbing.Hash]bool)
+	seenOids := make(map[string]bool)
+	var pointers []lfsPointer
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		commit, err := resolveCommit(repo, ref.Hash())
+		if err != nil || seenCommits[commit.Hash] {
+			// Refs to non-commits (i.e. tagged blobs) have no LFS content
+			return nil
+		}
+		seenCommits[commit.Hash] = true
+
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+
+		return tree.Files().ForEach(func(file *object.File) error {
+			if file.Size >= lfsPointerMaxSize {
+				return nil
+			}
+
+			contents, err := file.Contents()
+			if err != nil {
+				return err
+			}
+
+			pointer, ok := parseLFSPointer(contents)
+			if ok && !seenOids[pointer.Oid] {
+				seenOids[pointer.Oid] = true
+				pointers = append(pointers, pointer)
+			}
+			return nil
+		})
+	})
+
+	return pointers, err
+}
+
+func resolveCommit(repo *git.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	tag, err := repo.TagObject(hash)
+	if err == nil {
+		return tag.Commit()
+	}
+	return repo.CommitObject(hash)
+}
+
+func parseLFSPointer(contents string) (lfsPointer, bool) {
+	if !strings.HasPrefix(contents, lfsPointerVersion) {
+		return lfsPointer{}, false
+	}
+
+	oid := lfsOidRegex.FindStringSubmatch(contents)
+	size := lfsSizeRegex.FindStringSubmatch(contents)
+	if oid == nil || size == nil {
+		return lfsPointer{}, false
+	}
+
+	parsedSize, err := strconv.ParseInt(size[1], 10, 64)
+	if err != nil {
+		return lfsPointer{}, false
+	}
+
+	return lfsPointer{Oid: oid[1], Size: parsedSize}, true
+}
+
+// lfsObjectPath returns where git-lfs stores an object in a repo
+func lfsObjectPath(gitPath, oid string) string {
+	return filepath.Join(gitPath, ".git", "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package git contains functions for interacting with git repositories
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	netHttp "net/http"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+)
+
+// provider handles the parts of pushing repos that differ between git servers.
+type provider interface {
+	// repoURL returns the URL a repo is pushed to (and pulled from) on the git server
+	repoURL(repoName string) string
+	// createRepo makes sure the repo exists before it is pushed
+	createRepo(repoName string) error
+	// grantReadAccess gives the pull-user read access to a pushed repo
+	grantReadAccess(repoName string) error
+	// createReadOnlyUser creates (or updates) the pull-user on the git server
+	createReadOnlyUser() error
+	// repoSource returns the upstream URL recorded on an existing repo, empty if the repo does not exist or has none
+	repoSource(repoName string) (string, error)
+	// setRepoSource records the upstream URL of a repo in its description
+	setRepoSource(repoName, sourceURL string) error
+	// listRepos returns the repos in the group Zarf pushes to
+	listRepos() ([]MirroredRepo, error)
+}
+
+// MirroredRepo is a repo on the git server along with the upstream repo it mirrors.
+type MirroredRepo struct {
+	Name   string
+	URL    string
+	Source string
+}
+
+// repoDescriptionPrefix marks the upstream URL in the description of the repos Zarf pushes
+const repoDescriptionPrefix = "Mirrored by Zarf from "
+
+// ValidateRepoNaming returns an error if the repo naming scheme is not supported.
+func ValidateRepoNaming(name string) error {
+	switch name {
+	case "", config.ZarfGitRepoNamingHash, config.ZarfGitRepoNamingFlat:
+		return nil
+	default:
+		return fmt.Errorf("unsupported repo naming %q, must be one of %s or %s", name, config.ZarfGitRepoNamingHash, config.ZarfGitRepoNamingFlat)
+	}
+}
+
+// ValidateProvider returns an error if the git server provider is not supported.
+func ValidateProvider(name string) error {
+	switch name {
+	case "", config.ZarfGitProviderGitea, config.ZarfGitProviderGitLab, config.ZarfGitProviderGeneric:
+		return nil
+	default:
+		return fmt.Errorf("unsupported git provider %q, must be one of %s, %s or %s", name, config.ZarfGitProviderGitea, config.ZarfGitProviderGitLab, config.ZarfGitProviderGeneric)
+	}
+}
+
+// provider returns the implementation for the configured git server, state without a provider is Gitea.
+func (g *Git) provider() provider {
+	switch g.Server.Provider {
+	case config.ZarfGitProviderGitLab:
+		return gitlab{g}
+	case config.ZarfGitProviderGeneric:
+		return generic{g}
+	default:
+		return gitea{g}
+	}
+}
+
+// CreateReadOnlyUser creates the pull-only user on the git server.
+func (g *Git) CreateReadOnlyUser() error {
+	return g.provider().createReadOnlyUser()
+}
+
+// ListMirroredRepos returns the repos on the git server and the upstream URLs recorded on them.
+func (g *Git) ListMirroredRepos() ([]MirroredRepo, error) {
+	return g.provider().listRepos()
+}
+
+// repoDescription returns the description recording the upstream URL of a repo
+func repoDescription(sourceURL string) string {
+	return repoDescriptionPrefix + sourceURL
+}
+
+// sourceFromDescription returns the upstream URL recorded in a repo description
+func sourceFromDescription(description string) string {
+	if !strings.HasPrefix(description, repoDescriptionPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(description, repoDescriptionPrefix))
+}
+
+// doJSONRequest sends a request to a git server API, decoding the response into out if given
+func doJSONRequest(method, endpoint string, body, out interface{}, setAuth func(*netHttp.Request)) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := netHttp.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return 0, err
+	}
+	setAuth(request)
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/json")
+
+	client := &netHttp.Client{Timeout: time.Second * 20}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(response.Body)
+	message.Debugf("%s %s:\n%s", method, endpoint, string(responseBody))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("got status code of %d during http request with body of: %s", response.StatusCode, string(responseBody))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(responseBody, out); err != nil {
+			return response.StatusCode, err
+		}
+	}
+
+	return response.StatusCode, nil
+}
+
+// group returns the group repos are created under, defaulting to the push-user.
+func (g *Git) group() string {
+	if g.Server.Group != "" {
+		return g.Server.Group
+	}
+	return g.Server.PushUsername
+}
+
+// lfsURL returns the Git LFS API endpoint of a repo on the git server.
+func (g *Git) lfsURL(repoName string) string {
+	return strings.TrimSuffix(g.provider().repoURL(repoName), ".git") + ".git/info/lfs"
+}
+
+// generic is a plain smart HTTP git server, repos must already exist (or be created on push) and access is managed outside of Zarf.
+type generic struct {
+	*Git
+}
+
+func (g generic) repoURL(repoName string) string {
+	if g.Server.Group == "" {
+		return fmt.Sprintf("%s/%s.git", g.Server.Address, repoName)
+	}
+	return fmt.Sprintf("%s/%s/%s.git", g.Server.Address, g.Server.Group, repoName)
+}
+
+func (g generic) createRepo(_ string) error {
+	return nil
+}
+
+func (g generic) grantReadAccess(_ string) error {
+	return nil
+}
+
+func (g generic) createReadOnlyUser() error {
+	return fmt.Errorf("the %s git provider does not support creating users", config.ZarfGitProviderGeneric)
+}
+
+// repoSource is always empty as a generic server has no API to read repo descriptions
+func (g generic) repoSource(_ string) (string, error) {
+	return "", nil
+}
+
+func (g generic) setRepoSource(_, _ string) error {
+	return nil
+}
+
+func (g generic) listRepos() ([]MirroredRepo, error) {
+	return nil, fmt.Errorf("the %s git provider does not support listing repos", config.ZarfGitProviderGeneric)
+}
@@ -8,9 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
-	"regexp"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cache"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/go-git/go-git/v5"
@@ -47,6 +47,9 @@ func (g *Git) Pull(gitURL, targetFolder string) (path string, err error) {
 func (g *Git) pull(gitURL, targetFolder string, repoName string) {
 	g.Spinner.Updatef("Processing git repo %s", gitURL)
 
+	// Handle scp-like ssh URLs (git@host:path) the same as ssh:// URLs
+	gitURL = normalizeURL(gitURL)
+
 	gitCachePath := targetFolder
 	if repoName != "" {
 		gitCachePath = filepath.Join(config.GetAbsCachePath(), filepath.Join(config.ZarfGitCacheDir, repoName))
@@ -81,6 +84,8 @@ func (g *Git) pull(gitURL, targetFolder string, repoName string) {
 	}
 
 	if gitCachePath != targetFolder {
+		cache.MarkRepoUsed(gitCachePath)
+
 		err = utils.CreatePathAndCopy(gitCachePath, targetFolder)
 		if err != nil {
 			message.Fatalf(err, "Unable to copy %s into %s: %#v", gitCachePath, targetFolder, err.Error())
@@ -108,8 +113,6 @@ func (g *Git) pull(gitURL, targetFolder string, repoName string) {
 		_, _ = g.removeLocalBranchRefs()
 		_, _ = g.removeOnlineRemoteRefs()
 
-		var isHash = regexp.MustCompile(`^[0-9a-f]{40}$`).MatchString
-
 		if isHash(ref) {
 			g.fetchHash(ref)
 			g.checkoutHashAsBranch(plumbing.NewHash(ref), trunkBranchName)
@@ -8,15 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
 	"github.com/go-git/go-git/v5"
 	goConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
-func (g *Git) PushRepo(localPath string) error {
+// PushRepo pushes a packaged repo to the git server and returns the refs it pushed.
+func (g *Git) PushRepo(localPath string) (pushed types.PushedRepo, err error) {
 	spinner := message.NewProgressSpinner("Processing git repo at %s", localPath)
 	defer spinner.Stop()
 
@@ -27,38 +31,60 @@ func (g *Git) PushRepo(localPath string) error {
 	repo, err := g.prepRepoForPush()
 	if err != nil {
 		message.Warnf("error when prepping the repo for push.. %v", err)
-		return err
+		return pushed, err
 	}
 
-	if err := g.push(repo, spinner); err != nil {
+	// Get the upstream URL to name the repo on the git server and record where it came from
+	remote, err := repo.Remote(onlineRemoteName)
+	if err != nil {
+		return pushed, fmt.Errorf("unable to find the git remote: %w", err)
+	}
+	remoteURL := remote.Config().URLs[0]
+	repoName, err := g.mirrorRepoName(remoteURL)
+	if err != nil {
+		return pushed, fmt.Errorf("unable to transform the git url: %w", err)
+	}
+	sourceURL := stripPassword(remoteURL)
+
+	// Refuse to push over a different repo that ended up with the same name
+	existingSource, err := g.provider().repoSource(repoName)
+	if err != nil {
+		spinner.Warnf("Unable to check the git repo %s", repoName)
+		return pushed, err
+	}
+	if existingSource != "" && !sameRepo(existingSource, sourceURL) {
+		return pushed, fmt.Errorf("the git repo %s is already a mirror of %s, not %s", repoName, existingSource, sourceURL)
+	}
+
+	if err := g.provider().createRepo(repoName); err != nil {
+		spinner.Warnf("Unable to create the git repo %s", repoName)
+		return pushed, err
+	}
+
+	refs, err := g.push(repo, repoName, spinner)
+	if err != nil {
 		spinner.Warnf("Unable to push the git repo %s", basename)
-		return err
+		return pushed, err
 	}
 
-	// Add the read-only user to this repo
-	if g.Server.InternalServer {
-		// Get the upstream URL
-		remote, err := repo.Remote(onlineRemoteName)
-		if err != nil {
-			message.Warn("unable to get the information needed to add the read-only user to the repo")
-			return err
-		}
-		remoteUrl := remote.Config().URLs[0]
-		repoName, err := g.TransformURLtoRepoName(remoteUrl)
-		if err != nil {
-			message.Warnf("Unable to add the read-only user to the repo: %s\n", repoName)
-			return err
-		}
+	if err := g.pushLFS(repoName, spinner); err != nil {
+		spinner.Warnf("Unable to push the git LFS objects of %s", basename)
+		return pushed, err
+	}
 
-		err = g.addReadOnlyUserToRepo(g.Server.Address, repoName)
-		if err != nil {
-			message.Warnf("Unable to add the read-only user to the repo: %s\n", repoName)
-			return err
-		}
+	if err := g.provider().setRepoSource(repoName, sourceURL); err != nil {
+		spinner.Warnf("Unable to record the upstream URL of %s", basename)
+		return pushed, err
+	}
+
+	// Give the read-only user access to this repo
+	if err := g.provider().grantReadAccess(repoName); err != nil {
+		message.Warnf("Unable to add the read-only user to the repo: %s\n", repoName)
+		return pushed, err
 	}
 
 	spinner.Success()
-	return nil
+	return types.PushedRepo{Name: repoName, URL: sourceURL, Refs: refs}, nil
 }
 
 func (g *Git) prepRepoForPush() (*git.Repository, error) {
@@ -94,7 +120,9 @@ func (g *Git) prepRepoForPush() (*git.Repository, error) {
 	return repo, nil
 }
 
-func (g *Git) push(repo *git.Repository, spinner *message.Spinner) error {
+// push pushes the packaged branches and tags to the git server, leaving alone any refs that have new commits in the git server.
+// It returns the packaged refs and their hashes.
+func (g *Git) push(repo *git.Repository, repoName string, spinner *message.Spinner) (map[string]string, error) {
 	gitCred := http.BasicAuth{
 		Username: g.Server.PushUsername,
 		Password: g.Server.PushPassword,
@@ -105,54 +133,145 @@ func (g *Git) push(repo *git.Repository, spinner *message.Spinner) error {
 	// refs/remotes/online-upstream/master, will cause the push to fail)
 	removedRefs, err := g.removeHeadCopies()
 	if err != nil {
-		return fmt.Errorf("unable to remove unused git refs from the repo: %w", err)
+		return nil, fmt.Errorf("unable to remove unused git refs from the repo: %w", err)
 	}
 
-	// Fetch remote offline refs in case of old update or if multiple refs are specified in one package
-	fetchOptions := &git.FetchOptions{
-		RemoteName: offlineRemoteName,
-		Auth:       &gitCred,
-		RefSpecs: []goConfig.RefSpec{
-			"refs/heads/*:refs/heads/*",
-			onlineRemoteRefPrefix + "*:refs/heads/*",
-			"refs/tags/*:refs/tags/*",
-		},
+	// Add back the refs we removed just incase this push isn't the last thing
+	// being run and a later task needs to reference them.
+	defer g.addRefs(removedRefs)
+
+	packagedRefs, err := packagedRefs(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	offlineRemote, err := repo.Remote(offlineRemoteName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the git remote: %w", err)
 	}
 
-	// Attempt the fetch, if it fails, log a warning and continue trying to push (might as well try..)
-	err = repo.Fetch(fetchOptions)
-	if errors.Is(err, transport.ErrRepositoryNotFound) {
-		message.Debugf("Repo not yet available offline, skipping fetch...")
-	} else if errors.Is(err, git.ErrForceNeeded) {
-		message.Debugf("Repo fetch requires force, skipping fetch...")
-	} else if errors.Is(err, git.NoErrAlreadyUpToDate) {
-		message.Debugf("Repo already up-to-date, skipping fetch...")
+	// Find what is already in the git server, a repo that does not exist yet (or is empty) has nothing to compare against
+	serverRefs := make(map[plumbing.ReferenceName]plumbing.Hash)
+	remoteRefs, err := offlineRemote.List(&git.ListOptions{Auth: &gitCred})
+	if errors.Is(err, transport.ErrRepositoryNotFound) || errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		message.Debugf("Repo not yet available offline, skipping the divergence check...")
 	} else if err != nil {
-		return fmt.Errorf("unable to fetch the git repo prior to push: %w", err)
-	}
-
-	// Push all heads and tags to the offline remote
-	err = repo.Push(&git.PushOptions{
-		RemoteName: offlineRemoteName,
-		Auth:       &gitCred,
-		Progress:   spinner,
-		// If a provided refspec doesn't push anything, it is just ignored
-		RefSpecs: []goConfig.RefSpec{
-			"refs/heads/*:refs/heads/*",
-			onlineRemoteRefPrefix + "*:refs/heads/*",
-			"refs/tags/*:refs/tags/*",
-		},
-	})
+		return nil, fmt.Errorf("unable to list the refs in the git server: %w", err)
+	}
+	for _, ref := range remoteRefs {
+		serverRefs[ref.Name()] = ref.Hash()
+	}
+
+	var refSpecs []goConfig.RefSpec
+	var newerRefs []plumbing.ReferenceName
+	for target, local := range packagedRefs {
+		serverHash, exists := serverRefs[target]
+		switch {
+		case !exists:
+			refSpecs = append(refSpecs, goConfig.RefSpec(fmt.Sprintf("%s:%s", local.Name(), target)))
+		case serverHash == local.Hash():
+			continue
+		case target.IsBranch() && isAncestor(repo, serverHash, local.Hash()):
+			// The package only adds commits on top of what is in the git server
+			refSpecs = append(refSpecs, goConfig.RefSpec(fmt.Sprintf("%s:%s", local.Name(), target)))
+		default:
+			newerRefs = append(newerRefs, target)
+		}
+	}
+
+	// Changes made in the git server are kept rather than overwritten, let the user know how to get them back upstream
+	for _, ref := range newerRefs {
+		message.Warnf("The %s %s of %s in the git server has changes that are not in this package and will not be updated. "+
+			"Run 'zarf tools git export %s' to bundle them up for the upstream repo.", refKind(ref), ref.Short(), repoName, repoName)
+	}
 
-	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+	if len(refSpecs) == 0 {
 		spinner.Debugf("Repo already up-to-date")
-	} else if err != nil {
-		return fmt.Errorf("unable to push repo to the gitops service: %w", err)
+	} else {
+		err = repo.Push(&git.PushOptions{
+			RemoteName: offlineRemoteName,
+			Auth:       &gitCred,
+			Progress:   spinner,
+			RefSpecs:   refSpecs,
+		})
+
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			spinner.Debugf("Repo already up-to-date")
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to push repo to the gitops service: %w", err)
+		}
 	}
 
-	// Add back the refs we removed just incase this push isn't the last thing
-	// being run and a later task needs to reference them.
-	g.addRefs(removedRefs)
+	pushedRefs := make(map[string]string)
+	for target, local := range packagedRefs {
+		pushedRefs[target.String()] = local.Hash().String()
+	}
+
+	return pushedRefs, nil
+}
+
+// packagedRefs returns the local refs that are pushed keyed by the ref they are pushed to
+func packagedRefs(repo *git.Repository) (map[plumbing.ReferenceName]*plumbing.Reference, error) {
+	references, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify references when getting the repo's references: %w", err)
+	}
+
+	refs := make(map[plumbing.ReferenceName]*plumbing.Reference)
+	upstreamRefs := make(map[plumbing.ReferenceName]*plumbing.Reference)
+	err = references.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		name := ref.Name().String()
+		switch {
+		case ref.Name().IsBranch(), ref.Name().IsTag():
+			refs[ref.Name()] = ref
+		case strings.HasPrefix(name, onlineRemoteRefPrefix):
+			upstreamRefs[plumbing.NewBranchReferenceName(strings.TrimPrefix(name, onlineRemoteRefPrefix))] = ref
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the repo's references: %w", err)
+	}
+
+	// A local branch takes precedence over the upstream branch of the same name
+	for target, ref := range upstreamRefs {
+		if _, exists := refs[target]; !exists {
+			refs[target] = ref
+		}
+	}
+
+	return refs, nil
+}
+
+// isAncestor returns true if the commit in the git server is part of the history of the packaged commit
+func isAncestor(repo *git.Repository, serverHash, localHash plumbing.Hash) bool {
+	// The git server commit is unknown locally when it has commits that are not in the package
+	serverCommit, err := repo.CommitObject(serverHash)
+	if err != nil {
+		return false
+	}
 
-	return nil
+	localCommit, err := repo.CommitObject(localHash)
+	if err != nil {
+		return false
+	}
+
+	isAncestor, err := serverCommit.IsAncestor(localCommit)
+	if err != nil {
+		message.Debugf("Unable to compare %s and %s: %s", serverHash, localHash, err.Error())
+		return false
+	}
+	return isAncestor
+}
+
+// refKind returns a readable name for the kind of ref
+func refKind(ref plumbing.ReferenceName) string {
+	if ref.IsTag() {
+		return "tag"
+	}
+	return "branch"
 }
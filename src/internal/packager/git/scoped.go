@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package git contains functions for interacting with git repositories
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/go-git/go-git/v5"
+	goConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var isHash = regexp.MustCompile(`^[0-9a-f]{40}$`).MatchString
+
+// commitRefPrefix prefixes the refs a commit is fetched into, they are neither branches nor tags so they are not pushed
+const commitRefPrefix = "refs/zarf/commits/"
+
+// PullRepo clones a repo into the target folder, honoring the branch/tag allowlists and LFS options of the repo
+func (g *Git) PullRepo(repo types.ZarfRepo, targetFolder string) (path string, err error) {
+	repoName, err := g.TransformURLtoRepoName(repo.URL)
+	if err != nil {
+		return "", fmt.Errorf("unable to pull the git repo at %s: %w", repo.URL, err)
+	}
+
+	// Only unscoped pulls go through the repo cache
+	var cachePath string
+
+	if repo.Scoped() {
+		path = targetFolder + "/" + repoName
+		g.GitPath = path
+
+		if err := g.pullScoped(repo, path); err != nil {
+			return "", err
+		}
+	} else {
+		if path, err = g.Pull(repo.URL, targetFolder); err != nil {
+			return "", err
+		}
+		cachePath = filepath.Join(config.GetAbsCachePath(), config.ZarfGitCacheDir, repoName)
+	}
+
+	if repo.LFS {
+		g.GitPath = path
+		if err := g.fetchLFS(cachePath); err != nil {
+			return "", fmt.Errorf("unable to fetch the LFS objects of %s: %w", repo.URL, err)
+		}
+	}
+
+	return path, nil
+}
+
+// pullScoped fetches only the selected refs of a repo (with their full history) into the target folder.
+// Scoped pulls bypass the repo cache since what is fetched depends on the repo options.
+// Shallow fetches are not supported as go-git can't push the commits at their boundary to the git server.
+func (g *Git) pullScoped(repo types.ZarfRepo, targetFolder string) error {
+	message.Debugf("git.pullScoped(%#v, %s)", repo, targetFolder)
+
+	gitURL := normalizeURL(repo.URL)
+	g.Spinner.Updatef("Processing git repo %s", gitURL)
+
+	matches := gitURLRegex.FindStringSubmatch(gitURL)
+	idx := gitURLRegex.SubexpIndex
+	if len(matches) == 0 {
+		return fmt.Errorf("unable to get extract the repoName from the url %s", gitURL)
+	}
+
+	ref := matches[idx("ref")]
+	gitURLNoRef := fmt.Sprintf("%s%s/%s%s", matches[idx("proto")], matches[idx("hostPath")], matches[idx("repo")], matches[idx("git")])
+
+	_ = os.RemoveAll(targetFolder)
+	gitRepo, err := git.PlainInit(targetFolder, false)
+	if err != nil {
+		return fmt.Errorf("unable to create the git repo: %w", err)
+	}
+
+	remote, err := gitRepo.CreateRemote(&goConfig.RemoteConfig{
+		Name: onlineRemoteName,
+		URLs: []string{gitURLNoRef},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create the online remote: %w", err)
+	}
+
+	gitCred := g.FindAuthForHost(gitURLNoRef)
+
+	remoteRefs, err := remote.List(&git.ListOptions{Auth: gitCred.Auth})
+	if err != nil {
+		return fmt.Errorf("unable to list the refs of %s: %w", gitURLNoRef, err)
+	}
+
+	var refspecs []goConfig.RefSpec
+	var branches []string
+	var tags []string
+	trunkBranchName := plumbing.NewBranchReferenceName("master")
+
+	for _, remoteRef := range remoteRefs {
+		name := remoteRef.Name()
+
+		switch {
+		case name == plumbing.HEAD && remoteRef.Type() == plumbing.SymbolicReference:
+			trunkBranchName = remoteRef.Target()
+		case name.IsBranch() && matchesAny(name.Short(), repo.Branches):
+			branches = append(branches, name.Short())
+			refspecs = append(refspecs, goConfig.RefSpec(fmt.Sprintf("+%s:%s%s", name, onlineRemoteRefPrefix, name.Short())))
+		case name.IsTag() && (name.Short() == ref || matchesAny(name.Short(), repo.Tags)):
+			tags = append(tags, name.Short())
+			refspecs = append(refspecs, goConfig.RefSpec(fmt.Sprintf("+%s:%s", name, name)))
+		}
+	}
+
+	// A commit is fetched into a ref of its own that is neither a branch nor a tag, so it is only pushed as the trunk branch.
+	// Servers only have to serve the commits at the tip of their refs, so those are fetched through the ref.
+	var commitRefSpecs []goConfig.RefSpec
+	if isHash(ref) {
+		commitRefSpec := goConfig.RefSpec(fmt.Sprintf("%s:%s", ref, commitRefPrefix+ref))
+		for _, remoteRef := range remoteRefs {
+			if remoteRef.Type() == plumbing.HashReference && remoteRef.Hash().String() == ref {
+				commitRefSpec = goConfig.RefSpec(fmt.Sprintf("+%s:%s", remoteRef.Name(), commitRefPrefix+ref))
+				break
+			}
+		}
+		commitRefSpecs = append(commitRefSpecs, commitRefSpec)
+	}
+
+	if len(refspecs) == 0 && len(commitRefSpecs) == 0 {
+		return fmt.Errorf("no branches or tags in %s match the repo options", gitURLNoRef)
+	}
+
+	fetchOptions := &git.FetchOptions{
+		RemoteName: onlineRemoteName,
+		RefSpecs:   append(refspecs, commitRefSpecs...),
+		Tags:       git.NoTags,
+		Progress:   g.Spinner,
+		Auth:       gitCred.Auth,
+	}
+
+	err = gitRepo.Fetch(fetchOptions)
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		// Without fetching commits by hash every branch is fetched to find it (into refs that are not pushed)
+		fetchOptions.RefSpecs = append(refspecs, goConfig.RefSpec("+refs/heads/*:"+commitRefPrefix+"heads/*"))
+		err = gitRepo.Fetch(fetchOptions)
+	}
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("unable to fetch the selected refs of %s: %w", gitURLNoRef, err)
+	}
+
+	// Pick what HEAD points to, a HEAD is required to push the repo
+	var headHash plumbing.Hash
+	switch {
+	case ref != "":
+		// Match the unscoped @ref behavior, the ref is pushed as the trunk branch
+		revision := plumbing.Revision(ref)
+		if !isHash(ref) {
+			revision = plumbing.Revision(plumbing.NewTagReferenceName(ref))
+		}
+		hash, err := gitRepo.ResolveRevision(revision)
+		if err != nil {
+			return fmt.Errorf("unable to find %s in %s: %w", ref, gitURLNoRef, err)
+		}
+		headHash = *hash
+
+	case len(branches) > 0:
+		sort.Strings(branches)
+		headBranch := branches[0]
+		for _, branch := range branches {
+			if branch == trunkBranchName.Short() {
+				headBranch = branch
+			}
+		}
+		trunkBranchName = plumbing.NewBranchReferenceName(headBranch)
+
+		remoteRef, err := gitRepo.Reference(plumbing.ReferenceName(onlineRemoteRefPrefix+headBranch), true)
+		if err != nil {
+			return fmt.Errorf("unable to find branch %s in %s: %w", headBranch, gitURLNoRef, err)
+		}
+		headHash = remoteRef.Hash()
+
+	default:
+		// Only tags were selected, the latest one is pushed as the trunk branch
+		headTag := latestTag(tags)
+		hash, err := gitRepo.ResolveRevision(plumbing.Revision(plumbing.NewTagReferenceName(headTag)))
+		if err != nil {
+			return fmt.Errorf("unable to find tag %s in %s: %w", headTag, gitURLNoRef, err)
+		}
+		headHash = *hash
+	}
+
+	g.GitPath = targetFolder
+	g.checkoutHashAsBranch(headHash, trunkBranchName)
+
+	return nil
+}
+
+// latestTag returns the highest semver tag, or the last tag by name if none of the tags are semver
+func latestTag(tags []string) string {
+	sort.Strings(tags)
+	latest := tags[len(tags)-1]
+
+	var latestVersion *semver.Version
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		if latestVersion == nil || version.GreaterThan(latestVersion) {
+			latest = tag
+			latestVersion = version
+		}
+	}
+
+	return latest
+}
+
+// matchesAny returns true if the name matches any of the glob patterns
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched || pattern == name {
+			return true
+		}
+	}
+	return false
+}
@@ -7,11 +7,23 @@ package git
 import (
 	"fmt"
 	"hash/crc32"
+	"net/url"
 	"regexp"
+	"strings"
 
+	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 )
 
+// scpURLRegex matches scp-like ssh URLs (i.e. git@gitlab.com:group/repo.git), see https://git-scm.com/docs/git-clone#_git_urls
+var scpURLRegex = regexp.MustCompile(`^(?P<user>[\w\-\.]+@)?(?P<host>[\w\-\.]+):(?P<path>[^/\\].*)$`)
+
+// repoNameInvalidCharsRegex matches the characters git servers do not allow in repo names
+var repoNameInvalidCharsRegex = regexp.MustCompile(`[^\w\-\.]+`)
+
+// maxRepoNameLength is the longest repo name Gitea allows
+const maxRepoNameLength = 100
+
 // For further explanation: https://regex101.com/r/zq64q4/1
 var gitURLRegex = regexp.MustCompile(`^(?P<proto>[a-z]+:\/\/)(?P<hostPath>.+?)\/(?P<repo>[\w\-\.]+?)(?P<git>\.git)?(?P<atRef>@(?P<ref>[\w\-\.]+))?$`)
 
@@ -29,19 +41,45 @@ func (g *Git) MutateGitUrlsInText(text string) string {
 	return output
 }
 
-func (g *Git) TransformURLtoRepoName(url string) (string, error) {
+// normalizeURL converts scp-like ssh URLs to ssh:// URLs so all git URLs can be parsed the same way
+func normalizeURL(gitURL string) string {
+	if strings.Contains(gitURL, "://") {
+		return gitURL
+	}
+
+	matches := scpURLRegex.FindStringSubmatch(gitURL)
+	if len(matches) == 0 {
+		return gitURL
+	}
+
+	idx := scpURLRegex.SubexpIndex
+	return fmt.Sprintf("ssh://%s%s/%s", matches[idx("user")], matches[idx("host")], matches[idx("path")])
+}
+
+// sanitizeURL returns the repo name and the URL without its protocol and .git suffix
+func sanitizeURL(url string) (repoName string, sanitizedURL string, err error) {
+	url = normalizeURL(url)
 	matches := gitURLRegex.FindStringSubmatch(url)
 	idx := gitURLRegex.SubexpIndex
 
 	if len(matches) == 0 {
 		// Unable to find a substring match for the regex
-		return "", fmt.Errorf("unable to get extract the repoName from the url %s", url)
+		return "", "", fmt.Errorf("unable to get extract the repoName from the url %s", url)
 	}
 
-	repoName := matches[idx("repo")]
+	repoName = matches[idx("repo")]
 	// NOTE: We remove the .git and protocol so that https://zarf.dev/repo.git and http://zarf.dev/repo
 	// resolve to the same repp (as they would in real life)
-	sanitizedURL := fmt.Sprintf("%s/%s%s", matches[idx("hostPath")], repoName, matches[idx("atRef")])
+	sanitizedURL = fmt.Sprintf("%s/%s%s", matches[idx("hostPath")], repoName, matches[idx("atRef")])
+
+	return repoName, sanitizedURL, nil
+}
+
+func (g *Git) TransformURLtoRepoName(url string) (string, error) {
+	repoName, sanitizedURL, err := sanitizeURL(url)
+	if err != nil {
+		return "", err
+	}
 
 	// Add crc32 hash of the repoName to the end of the repo
 	table := crc32.MakeTable(crc32.IEEE)
@@ -51,12 +89,68 @@ func (g *Git) TransformURLtoRepoName(url string) (string, error) {
 	return newRepoName, nil
 }
 
+// flatRepoName turns the host and path of the URL into a readable repo name, i.e. github.com-stefanprodan-podinfo
+func flatRepoName(url string) (string, error) {
+	_, sanitizedURL, err := sanitizeURL(url)
+	if err != nil {
+		return "", err
+	}
+
+	// Drop any credentials, they should never end up in a repo name
+	if host, _, found := strings.Cut(sanitizedURL, "/"); found {
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			sanitizedURL = sanitizedURL[at+1:]
+		}
+	}
+
+	repoName := strings.Trim(repoNameInvalidCharsRegex.ReplaceAllString(sanitizedURL, "-"), "-.")
+
+	// Keep long names within the git server limits while still unique
+	if len(repoName) > maxRepoNameLength {
+		checksum := crc32.Checksum([]byte(sanitizedURL), crc32.MakeTable(crc32.IEEE))
+		suffix := fmt.Sprintf("-%d", checksum)
+		repoName = repoName[:maxRepoNameLength-len(suffix)] + suffix
+	}
+
+	return repoName, nil
+}
+
+// mirrorRepoName returns the name of the mirror of a repo on the git server using the configured naming scheme
+func (g *Git) mirrorRepoName(url string) (string, error) {
+	if g.Server.RepoNaming == config.ZarfGitRepoNamingFlat {
+		return flatRepoName(url)
+	}
+	return g.TransformURLtoRepoName(url)
+}
+
+// stripPassword removes any password (or token) from a URL so it can be shown and stored
+func stripPassword(gitURL string) string {
+	parsed, err := url.Parse(gitURL)
+	if err != nil || parsed.User == nil {
+		return gitURL
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = nil
+	}
+	return parsed.String()
+}
+
+// sameRepo returns true if both URLs point to the same repo, ignoring the protocol and .git suffix
+func sameRepo(a, b string) bool {
+	_, sanitizedA, errA := sanitizeURL(a)
+	_, sanitizedB, errB := sanitizeURL(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return sanitizedA == sanitizedB
+}
+
 func (g *Git) transformURL(url string) (string, error) {
-	repoName, err := g.TransformURLtoRepoName(url)
+	repoName, err := g.mirrorRepoName(url)
 	if err != nil {
 		return "", err
 	}
-	output := fmt.Sprintf("%s/%s/%s", g.Server.Address, g.Server.PushUsername, repoName)
+	output := g.provider().repoURL(repoName)
 	message.Debugf("Rewrite git URL: %s -> %s", url, output)
 	return output, nil
 }
@@ -5,6 +5,7 @@
 package helm
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/packager/template"
 	"github.com/defenseunicorns/zarf/src/types"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
@@ -162,8 +164,8 @@ func (h *Helm) TemplateChart() (string, error) {
 	return templatedChart.Manifest, nil
 }
 
-// GenerateChart generates a helm chart for a given Zarf manifest.
-func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings, string, error) {
+// GenerateChart generates a helm chart for a given Zarf manifest, Go templated manifests are rendered with the given values.
+func (h *Helm) GenerateChart(manifest types.ZarfManifest, values template.Values) (types.ConnectStrings, string, error) {
 	message.Debugf("helm.GenerateChart(%#v)", manifest)
 	spinner := message.NewProgressSpinner("Starting helm chart generation %s", manifest.Name)
 	defer spinner.Stop()
@@ -184,6 +186,7 @@ func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings,
 	tmpChart.Metadata.APIVersion = chart.APIVersionV1
 
 	// Add the manifest files so helm does its thing
+	isGoTemplate := manifest.GoTemplate
 	for _, file := range manifest.Files {
 		spinner.Updatef("Processing %s", file)
 		manifest := fmt.Sprintf("%s/%s", h.BasePath, file)
@@ -191,6 +194,13 @@ func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings,
 		if err != nil {
 			return nil, "", fmt.Errorf("unable to read manifest file %s: %w", manifest, err)
 		}
+		// Go templated manifests are rendered before helm sees them, so helm must output them as they are
+		if isGoTemplate {
+			if data, err = values.ExecuteGoTemplate(h.Component, file, data); err != nil {
+				return nil, "", fmt.Errorf("unable to template manifest file %s: %w", file, err)
+			}
+			data = escapeHelmTemplate(data)
+		}
 		tmpChart.Templates = append(tmpChart.Templates, &chart.File{Name: manifest, Data: data})
 	}
 
@@ -212,6 +222,11 @@ func (h *Helm) GenerateChart(manifest types.ZarfManifest) (types.ConnectStrings,
 	return h.InstallOrUpgradeChart()
 }
 
+// escapeHelmTemplate escapes text so helm renders it unchanged (e.g. a variable value containing {{)
+func escapeHelmTemplate(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte("{{"), []byte(`{{"{{"}}`))
+}
+
 func (h *Helm) installChart(postRender *renderer) (*release.Release, error) {
 	message.Debugf("helm.installChart(%#v)", postRender)
 	// Bind the helm action
@@ -309,6 +324,9 @@ func (h *Helm) loadChartData() (*chart.Chart, map[string]any, error) {
 		if err != nil {
 			return loadedChart, nil, fmt.Errorf("unable to parse chart values: %w", err)
 		}
+
+		// Values set by the user at deploy time take precedence over the packaged values
+		chartValues = MergeValues(chartValues, h.ValuesOverrides)
 		message.Debug(chartValues)
 	} else {
 		// Otherwise, use the overrides instead
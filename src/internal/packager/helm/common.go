@@ -21,6 +21,7 @@ type Helm struct {
 	ChartLoadOverride string
 	ChartOverride     *chart.Chart
 	ValueOverride     map[string]any
+	ValuesOverrides   map[string]any
 	Component         types.ZarfComponent
 	Cluster           *cluster.Cluster
 	Cfg               *types.PackagerConfig
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package helm contins operations for working with helm charts
+package helm
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// chartLockName is the name of the file helm records the resolved dependency versions in
+const chartLockName = "Chart.lock"
+
+// ChartDependencies returns the dependencies vendored in a chart tarball along with the version that was packaged.
+func ChartDependencies(tarballPath string) ([]types.ZarfChartDependency, error) {
+	loadedChart, err := loader.Load(tarballPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load helm chart archive: %w", err)
+	}
+
+	var dependencies []types.ZarfChartDependency
+	for _, dependency := range loadedChart.Metadata.Dependencies {
+		version := dependency.Version
+		for _, subchart := range loadedChart.Dependencies() {
+			if subchart.Name() == dependency.Name {
+				version = subchart.Metadata.Version
+			}
+		}
+
+		dependencies = append(dependencies, types.ZarfChartDependency{
+			Name:       dependency.Name,
+			Version:    version,
+			Repository: dependency.Repository,
+		})
+	}
+
+	return dependencies, nil
+}
+
+// vendorDependencies downloads the dependencies in the Chart.yaml of an unpacked chart that are not already in its charts directory.
+// The versions in an existing Chart.lock are kept, otherwise the resolved versions are written to a new Chart.lock.
+// Relative file:// dependencies are resolved from sourcePath, which is where the chart was copied from (if it was).
+func vendorDependencies(chartPath, sourcePath string, out io.Writer) error {
+	message.Debugf("helm.vendorDependencies(%s, %s)", chartPath, sourcePath)
+
+	loadedChart, err := loader.LoadDir(chartPath)
+	if err != nil {
+		return fmt.Errorf("unable to load the chart: %w", err)
+	}
+
+	// Nothing to do if every dependency is already in the charts directory
+	if action.CheckDependencies(loadedChart, loadedChart.Metadata.Dependencies) == nil {
+		return nil
+	}
+
+	registryClient, err := registry.NewClient(registry.ClientOptEnableCache(true))
+	if err != nil {
+		return fmt.Errorf("unable to create a registry client: %w", err)
+	}
+
+	settings := cli.New()
+	manager := &downloader.Manager{
+		Out:              out,
+		ChartPath:        chartPath,
+		Verify:           downloader.VerifyNever,
+		Getters:          getter.All(settings),
+		RegistryClient:   registryClient,
+		RepositoryConfig: settings.RepositoryConfig,
+		RepositoryCache:  settings.RepositoryCache,
+	}
+
+	// Charts using requirements.yaml are resolved as-is
+	if loadedChart.Metadata.APIVersion == chart.APIVersionV1 {
+		return manager.Update()
+	}
+
+	rewritten := false
+	for _, dependency := range loadedChart.Metadata.Dependencies {
+		// Pin the dependency to the version in the Chart.lock
+		if loadedChart.Lock != nil {
+			for _, locked := range loadedChart.Lock.Dependencies {
+				if locked.Name == dependency.Name && locked.Version != dependency.Version {
+					dependency.Version = locked.Version
+					rewritten = true
+				}
+			}
+		}
+
+		// Point relative file:// dependencies at where the chart came from
+		if localPath := strings.TrimPrefix(dependency.Repository, "file://"); sourcePath != chartPath && localPath != dependency.Repository && !filepath.IsAbs(localPath) {
+			dependency.Repository = "file://" + filepath.Join(sourcePath, localPath)
+			rewritten = true
+		}
+	}
+
+	if !rewritten {
+		return manager.Update()
+	}
+
+	// Put back the original Chart.yaml (and Chart.lock) once the rewritten dependencies are vendored
+	chartfilePath := filepath.Join(chartPath, chartutil.ChartfileName)
+	lockPath := filepath.Join(chartPath, chartLockName)
+	originalChartfile, err := os.ReadFile(chartfilePath)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", chartfilePath, err)
+	}
+	originalLock, lockErr := os.ReadFile(lockPath)
+	defer func() {
+		_ = os.WriteFile(chartfilePath, originalChartfile, 0600)
+		if lockErr == nil {
+			_ = os.WriteFile(lockPath, originalLock, 0600)
+		}
+	}()
+
+	if err := chartutil.SaveChartfile(chartfilePath, loadedChart.Metadata); err != nil {
+		return fmt.Errorf("unable to pin the chart dependencies: %w", err)
+	}
+
+	return manager.Update()
+}
+
+// vendorArchiveDependencies vendors any dependencies missing from a chart tarball, replacing the tarball if it changed.
+func vendorArchiveDependencies(tarballPath string, out io.Writer) error {
+	loadedChart, err := loader.Load(tarballPath)
+	if err != nil {
+		return fmt.Errorf("unable to load helm chart archive: %w", err)
+	}
+
+	if action.CheckDependencies(loadedChart, loadedChart.Metadata.Dependencies) == nil {
+		return nil
+	}
+
+	tmpPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return fmt.Errorf("unable to create tmpdir: %w", err)
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err := chartutil.ExpandFile(tmpPath, tarballPath); err != nil {
+		return fmt.Errorf("unable to expand the chart archive: %w", err)
+	}
+
+	chartPath := filepath.Join(tmpPath, loadedChart.Name())
+	if err := vendorDependencies(chartPath, chartPath, out); err != nil {
+		return err
+	}
+
+	client := action.NewPackage()
+	client.Destination = tmpPath
+	packaged, err := client.Run(chartPath, nil)
+	if err != nil {
+		return fmt.Errorf("unable to package the chart: %w", err)
+	}
+
+	return os.Rename(packaged, tarballPath)
+}
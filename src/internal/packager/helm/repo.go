@@ -8,14 +8,17 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/internal/packager/git"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli"
 
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/repo"
 )
 
@@ -30,10 +33,27 @@ func (h *Helm) CreateChartFromLocalFiles(destination string) string {
 		spinner.Fatalf(err, "Validation failed for chart from %s (%s)", h.Chart.LocalPath, err.Error())
 	}
 
+	// Work on a copy of the chart so vendoring its dependencies doesn't change the source directory
+	tempPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		spinner.Fatalf(err, "Unable to create tmpdir")
+	}
+	defer os.RemoveAll(tempPath)
+
+	chartPath := filepath.Join(tempPath, filepath.Base(h.Chart.LocalPath))
+	if err := utils.CreatePathAndCopy(h.Chart.LocalPath, chartPath); err != nil {
+		spinner.Fatalf(err, "Unable to copy the chart from %s", h.Chart.LocalPath)
+	}
+
+	sourcePath, _ := filepath.Abs(h.Chart.LocalPath)
+	if err := vendorDependencies(chartPath, sourcePath, spinner); err != nil {
+		spinner.Fatalf(err, "Unable to vendor the dependencies of chart %s", h.Chart.Name)
+	}
+
 	client := action.NewPackage()
 
 	client.Destination = destination
-	path, err := client.Run(h.Chart.LocalPath, nil)
+	path, err := client.Run(chartPath, nil)
 
 	if err != nil {
 		spinner.Fatalf(err, "Helm is unable to save the archive and create the package %s", path)
@@ -67,9 +87,14 @@ func (h *Helm) DownloadChartFromGit(destination string) string {
 		spinner.Fatalf(err, "Validation failed for chart %s (%s)", h.Chart.Name, err.Error())
 	}
 
+	chartPath := filepath.Join(tempPath, h.Chart.GitPath)
+	if err := vendorDependencies(chartPath, chartPath, spinner); err != nil {
+		spinner.Fatalf(err, "Unable to vendor the dependencies of chart %s", h.Chart.Name)
+	}
+
 	// Tell helm where to save the archive and create the package
 	client.Destination = destination
-	name, err := client.Run(filepath.Join(tempPath, h.Chart.GitPath), nil)
+	name, err := client.Run(chartPath, nil)
 
 	if err != nil {
 		spinner.Fatalf(err, "Helm is unable to save the archive and create the package %s", name)
@@ -96,16 +121,27 @@ func (h *Helm) DownloadPublishedChart(destination string) {
 		Getters: getter.All(pull.Settings),
 	}
 
-	// @todo: process OCI-based charts
-
-	// Perform simple chart download
-	chartURL, err := repo.FindChartInRepoURL(h.Chart.Url, h.Chart.Name, h.Chart.Version, pull.CertFile, pull.KeyFile, pull.CaFile, getter.All(pull.Settings))
-	if err != nil {
-		spinner.Fatalf(err, "Unable to pull the helm chart")
+	var chartURL string
+	if registry.IsOCI(h.Chart.Url) {
+		// OCI charts are pulled straight from the registry, using any docker credentials on the host
+		registryClient, err := registry.NewClient(registry.ClientOptEnableCache(true))
+		if err != nil {
+			spinner.Fatalf(err, "Unable to create a registry client for the helm chart")
+		}
+		chartDownloader.RegistryClient = registryClient
+		chartDownloader.Options = append(chartDownloader.Options, getter.WithRegistryClient(registryClient))
+		chartURL = h.Chart.Url
+	} else {
+		// Perform simple chart download
+		var err error
+		chartURL, err = repo.FindChartInRepoURL(h.Chart.Url, h.Chart.Name, h.Chart.Version, pull.CertFile, pull.KeyFile, pull.CaFile, getter.All(pull.Settings))
+		if err != nil {
+			spinner.Fatalf(err, "Unable to pull the helm chart")
+		}
 	}
 
 	// Download the file (we don't control what name helm creates here)
-	saved, _, err := chartDownloader.DownloadTo(chartURL, pull.Version, destination)
+	saved, _, err := chartDownloader.DownloadTo(chartURL, h.Chart.Version, destination)
 	if err != nil {
 		spinner.Fatalf(err, "Unable to download the helm chart")
 	}
@@ -116,6 +152,11 @@ func (h *Helm) DownloadPublishedChart(destination string) {
 		spinner.Fatalf(err, "Validation failed for chart %s (%s)", h.Chart.Name, err.Error())
 	}
 
+	// Published charts normally include their dependencies, vendor any that were left out
+	if err := vendorArchiveDependencies(saved, spinner); err != nil {
+		spinner.Fatalf(err, "Unable to vendor the dependencies of chart %s", h.Chart.Name)
+	}
+
 	// Ensure the name is consistent for deployments
 	destinationTarball := StandardName(destination, h.Chart) + ".tgz"
 	err = os.Rename(saved, destinationTarball)
@@ -79,3 +79,23 @@ func (h *Helm) createActionConfig(namespace string, spinner *message.Spinner) er
 
 	return err
 }
+
+// MergeValues merges the override values on top of the base values, nested maps are merged instead of replaced
+func MergeValues(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range override {
+		if overrideMap, ok := value.(map[string]any); ok {
+			if baseMap, ok := merged[key].(map[string]any); ok {
+				merged[key] = MergeValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images
+package images
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	ggcrTypes "github.com/google/go-containerregistry/pkg/v1/types"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// chartManifest is the raw OCI manifest of a helm chart
+type chartManifest []byte
+
+func (m chartManifest) RawManifest() ([]byte, error) {
+	return m, nil
+}
+
+func (m chartManifest) MediaType() (ggcrTypes.MediaType, error) {
+	return ggcrTypes.OCIManifestSchema1, nil
+}
+
+// ChartSource returns the path (and tag) a helm chart is pushed to in the Zarf registry
+func ChartSource(chart types.ZarfChart) string {
+	return fmt.Sprintf("%s/%s:%s", config.ZarfRegistryChartsPath, chart.Name, chart.Version)
+}
+
+// PushChartToZarfRegistry pushes a helm chart tarball into the configured Zarf registry as an OCI artifact
+func (i *ImgConfig) PushChartToZarfRegistry(chart types.ZarfChart, tarballPath string) error {
+	message.Debugf("images.PushChartToZarfRegistry(%#v, %s)", chart, tarballPath)
+
+	registryURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	spinner := message.NewProgressSpinner("Storing helm chart %s:%s in the zarf registry", chart.Name, chart.Version)
+	defer spinner.Stop()
+
+	chartData, err := os.ReadFile(tarballPath)
+	if err != nil {
+		return fmt.Errorf("unable to read the chart tarball %s: %w", tarballPath, err)
+	}
+
+	// The chart metadata is the config of the artifact, the same as `helm push` creates
+	loadedChart, err := loader.LoadArchive(bytes.NewReader(chartData))
+	if err != nil {
+		return fmt.Errorf("unable to load the chart tarball %s: %w", tarballPath, err)
+	}
+	configData, err := json.Marshal(loadedChart.Metadata)
+	if err != nil {
+		return fmt.Errorf("unable to encode the chart metadata: %w", err)
+	}
+
+	ref, err := name.ParseReference(fmt.Sprintf("%s/%s", registryURL, ChartSource(chart)), name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("unable to parse the chart reference: %w", err)
+	}
+
+	remoteOptions := crane.GetOptions(i.registryCraneOptions()...).Remote
+
+	configLayer := static.NewLayer(configData, registry.ConfigMediaType)
+	chartLayer := static.NewLayer(chartData, registry.ChartLayerMediaType)
+
+	manifest := v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     ggcrTypes.OCIManifestSchema1,
+	}
+	for idx, layer := range []v1.Layer{configLayer, chartLayer} {
+		if err := remote.WriteLayer(ref.Context(), layer, remoteOptions...); err != nil {
+			return fmt.Errorf("unable to push the chart to the registry: %w", err)
+		}
+
+		descriptor, err := layerDescriptor(layer)
+		if err != nil {
+			return err
+		}
+		if idx == 0 {
+			manifest.Config = descriptor
+		} else {
+			manifest.Layers = append(manifest.Layers, descriptor)
+		}
+	}
+
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to encode the chart manifest: %w", err)
+	}
+
+	if err := remote.Put(ref, chartManifest(rawManifest), remoteOptions...); err != nil {
+		return fmt.Errorf("unable to push the chart manifest to the registry: %w", err)
+	}
+
+	spinner.Success()
+	return nil
+}
+
+// layerDescriptor returns the OCI descriptor of a layer
+func layerDescriptor(layer v1.Layer) (v1.Descriptor, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	mediaType, err := layer.MediaType()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	return v1.Descriptor{MediaType: mediaType, Digest: digest, Size: size}, nil
+}
@@ -4,7 +4,14 @@
 // Package images provides functions for building and pushing images
 package images
 
-import "github.com/defenseunicorns/zarf/src/types"
+import (
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+)
 
 type ImgConfig struct {
 	TarballPath string
@@ -16,8 +23,109 @@ type ImgConfig struct {
 	NoChecksum bool
 
 	Insecure bool
+
+	// MaxCacheSize is the size in bytes the image and repo cache is trimmed to after pulling (0 is unlimited)
+	MaxCacheSize int64
+}
+
+// DeployedImage is an image recorded by a deployed package and how it was named when pushed to the registry
+type DeployedImage struct {
+	Source     string
+	Package    string
+	Component  string
+	NoChecksum bool
 }
 
 func New(config *ImgConfig) *ImgConfig {
 	return config
 }
+
+// GetDeployedImages returns every image (and helm chart pushed to the registry) referenced by the deployed components of the given packages
+func GetDeployedImages(deployedPackages []types.DeployedPackage) []DeployedImage {
+	var deployedImages []DeployedImage
+
+	for _, pkg := range deployedPackages {
+		isInitConfig := pkg.Data.Kind == "ZarfInitConfig"
+
+		for _, deployedComponent := range pkg.DeployedComponents {
+			for _, component := range pkg.Data.Components {
+				if component.Name != deployedComponent.Name {
+					continue
+				}
+
+				// The agent images are pushed without a checksum so the agent doesn't mutate itself
+				noChecksum := isInitConfig && component.Name == "zarf-agent"
+				for _, image := range component.Images {
+					deployedImages = append(deployedImages, DeployedImage{
+						Source:     image,
+						Package:    pkg.Name,
+						Component:  component.Name,
+						NoChecksum: noChecksum,
+					})
+				}
+
+				// Charts pushed to the registry are stored under a fixed path without a checksum
+				for _, chart := range component.Charts {
+					if chart.PushToRegistry {
+						deployedImages = append(deployedImages, DeployedImage{
+							Source:     ChartSource(chart),
+							Package:    pkg.Name,
+							Component:  component.Name,
+							NoChecksum: true,
+						})
+					}
+				}
+
+				// The seed image is pushed during init and is what the permanent registry runs on
+				if isInitConfig && component.Name == "zarf-seed-registry" {
+					deployedImages = append(deployedImages, DeployedImage{
+						Source:     fmt.Sprintf("%s:%s", config.ZarfSeedImage, config.ZarfSeedTag),
+						Package:    pkg.Name,
+						Component:  component.Name,
+						NoChecksum: true,
+					})
+				}
+			}
+		}
+	}
+
+	return deployedImages
+}
+
+// RegistryName returns the name this image was given when it was pushed to the registry at the given host
+func (d DeployedImage) RegistryName(host string) (string, error) {
+	if d.NoChecksum {
+		return utils.SwapHostWithoutChecksum(d.Source, host)
+	}
+	return utils.SwapHost(d.Source, host)
+}
+
+// connectToRegistry returns an address for the configured registry, opening a tunnel when it lives inside the cluster
+// Note: the returned tunnel is nil if one was not needed and should otherwise be closed by the caller
+func (i *ImgConfig) connectToRegistry() (string, *cluster.Tunnel, error) {
+	if i.RegInfo.InternalRegistry {
+		// Establish a registry tunnel to reach the zarf registry
+		tunnel, err := cluster.NewZarfTunnel()
+		if err != nil {
+			return "", nil, err
+		}
+		if err := tunnel.Connect(cluster.ZarfRegistry, false); err != nil {
+			return "", nil, err
+		}
+		return tunnel.Endpoint(), tunnel, nil
+	}
+
+	if cluster.IsServiceURL(i.RegInfo.Address) {
+		// If this is a serviceURL, create a port-forward tunnel to that resource
+		tunnel, err := cluster.NewTunnelFromServiceURL(i.RegInfo.Address)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := tunnel.Connect("", false); err != nil {
+			return "", nil, err
+		}
+		return tunnel.Endpoint(), tunnel, nil
+	}
+
+	return i.RegInfo.Address, nil, nil
+}
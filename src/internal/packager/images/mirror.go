@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images
+package images
+
+import (
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/crane"
+)
+
+// MirrorToRegistry copies the given deployed images from the configured Zarf registry into the target registry
+func (i *ImgConfig) MirrorToRegistry(deployedImages []DeployedImage, target types.RegistryInfo) error {
+	message.Debugf("images.MirrorToRegistry(%d images, %s)", len(deployedImages), target.Address)
+
+	sourceURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	spinner := message.NewProgressSpinner("Mirroring %d images to %s", len(deployedImages), target.Address)
+	defer spinner.Stop()
+
+	pullOptions := append(config.GetCraneOptions(i.Insecure), config.GetCraneAuthOption(i.RegInfo.PushUsername, i.RegInfo.PushPassword))
+	pushOptions := append(config.GetCraneOptions(i.Insecure), config.GetCraneAuthOption(target.PushUsername, target.PushPassword))
+
+	mirrored := make(map[string]bool)
+	for idx, image := range deployedImages {
+		sourceName, err := image.RegistryName(sourceURL)
+		if err != nil {
+			return fmt.Errorf("unable to determine the Zarf registry name for %s: %w", image.Source, err)
+		}
+
+		targetName, err := image.RegistryName(target.Address)
+		if err != nil {
+			return fmt.Errorf("unable to determine the target registry name for %s: %w", image.Source, err)
+		}
+
+		// The same image can be shared across packages, only copy it once
+		if mirrored[targetName] {
+			continue
+		}
+
+		spinner.Updatef("Mirroring image (%d of %d): %s", idx+1, len(deployedImages), image.Source)
+		message.Debugf("crane.Pull(%s) -> crane.Push(%s)", sourceName, targetName)
+
+		img, err := crane.Pull(sourceName, pullOptions...)
+		if err != nil {
+			return fmt.Errorf("unable to pull %s from the Zarf registry: %w", image.Source, err)
+		}
+
+		if err := crane.Push(img, targetName, pushOptions...); err != nil {
+			return fmt.Errorf("unable to push %s to %s: %w", image.Source, target.Address, err)
+		}
+
+		mirrored[targetName] = true
+	}
+
+	spinner.Successf("Mirrored %d images to %s", len(mirrored), target.Address)
+	return nil
+}
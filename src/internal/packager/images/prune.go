@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images
+package images
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// PruneCandidate is a manifest in the registry that is not referenced by any deployed package
+type PruneCandidate struct {
+	Repository string
+	Digest     string
+	Tags       []string
+	Size       int64
+}
+
+// registryManifest tracks a manifest found while walking the registry catalog
+type registryManifest struct {
+	repository string
+	digest     string
+	tags       []string
+	blobs      map[string]int64
+}
+
+// PruneRegistry finds (and unless dryRun is set, deletes) all manifests in the configured registry that are not
+// referenced by the given deployed images. The returned size is the amount of blob storage no longer referenced.
+// Note: the registry must still be garbage collected for the blob storage to actually be reclaimed.
+func (i *ImgConfig) PruneRegistry(deployedImages []DeployedImage, dryRun bool) ([]PruneCandidate, int64, error) {
+	message.Debugf("images.PruneRegistry(%d images, %t)", len(deployedImages), dryRun)
+
+	registryURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return nil, 0, fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	spinner := message.NewProgressSpinner("Looking for images that are no longer referenced by a deployed package")
+	defer spinner.Stop()
+
+	craneOptions := i.registryCraneOptions()
+
+	// Build the list of references the deployed packages expect to find in the registry
+	referenced := make(map[string]bool)
+	for _, image := range deployedImages {
+		registryName, err := image.RegistryName(registryURL)
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to determine the registry name for %s: %w", image.Source, err)
+		}
+		referenced[registryName] = true
+	}
+
+	manifests, err := i.listRegistryManifests(registryURL, craneOptions, spinner)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Track the blobs that are still in use by manifests we are keeping
+	keepBlobs := make(map[string]bool)
+	var candidates []registryManifest
+	for _, manifest := range manifests {
+		keep := referenced[fmt.Sprintf("%s/%s@%s", registryURL, manifest.repository, manifest.digest)]
+		for _, tag := range manifest.tags {
+			keep = keep || referenced[fmt.Sprintf("%s/%s:%s", registryURL, manifest.repository, tag)]
+		}
+
+		if keep {
+			for blob := range manifest.blobs {
+				keepBlobs[blob] = true
+			}
+		} else {
+			candidates = append(candidates, manifest)
+		}
+	}
+
+	// Only count the space of blobs that will no longer be referenced by anything (and only count them once)
+	var reclaimable int64
+	var pruned []PruneCandidate
+	countedBlobs := make(map[string]bool)
+	for _, manifest := range candidates {
+		candidate := PruneCandidate{
+			Repository: manifest.repository,
+			Digest:     manifest.digest,
+			Tags:       manifest.tags,
+		}
+
+		for blob, size := range manifest.blobs {
+			if keepBlobs[blob] || countedBlobs[blob] {
+				continue
+			}
+			countedBlobs[blob] = true
+			candidate.Size += size
+		}
+		reclaimable += candidate.Size
+
+		pruned = append(pruned, candidate)
+	}
+
+	if dryRun {
+		spinner.Successf("Found %d unreferenced images in the registry", len(pruned))
+		return pruned, reclaimable, nil
+	}
+
+	for idx, candidate := range pruned {
+		ref := fmt.Sprintf("%s/%s@%s", registryURL, candidate.Repository, candidate.Digest)
+		spinner.Updatef("Deleting image (%d of %d): %s", idx+1, len(pruned), ref)
+		if err := crane.Delete(ref, craneOptions...); err != nil {
+			return nil, 0, fmt.Errorf("unable to delete %s from the registry: %w", ref, err)
+		}
+	}
+
+	spinner.Successf("Deleted %d unreferenced images from the registry", len(pruned))
+	return pruned, reclaimable, nil
+}
+
+// listRegistryManifests walks the registry catalog and returns every tagged manifest with the blobs it references
+func (i *ImgConfig) listRegistryManifests(registryURL string, craneOptions []crane.Option, spinner *message.Spinner) ([]registryManifest, error) {
+	repositories, err := crane.Catalog(registryURL, craneOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the registry catalog: %w", err)
+	}
+
+	var manifests []registryManifest
+	for _, repository := range repositories {
+		spinner.Updatef("Reading tags for %s", repository)
+
+		repoName := fmt.Sprintf("%s/%s", registryURL, repository)
+		tags, err := crane.ListTags(repoName, craneOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the tags for %s: %w", repository, err)
+		}
+
+		// Group the tags by the manifest they point to
+		byDigest := make(map[string]*registryManifest)
+		var order []string
+		for _, tag := range tags {
+			digest, err := crane.Digest(fmt.Sprintf("%s:%s", repoName, tag), craneOptions...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the digest for %s:%s: %w", repository, tag, err)
+			}
+
+			if manifest, ok := byDigest[digest]; ok {
+				manifest.tags = append(manifest.tags, tag)
+				continue
+			}
+
+			blobs, err := getManifestBlobs(fmt.Sprintf("%s@%s", repoName, digest), craneOptions)
+			if err != nil {
+				return nil, err
+			}
+
+			byDigest[digest] = &registryManifest{
+				repository: repository,
+				digest:     digest,
+				tags:       []string{tag},
+				blobs:      blobs,
+			}
+			order = append(order, digest)
+		}
+
+		for _, digest := range order {
+			manifests = append(manifests, *byDigest[digest])
+		}
+	}
+
+	return manifests, nil
+}
+
+// getManifestBlobs returns the config and layer blobs of an image manifest along with their sizes
+func getManifestBlobs(ref string, craneOptions []crane.Option) (map[string]int64, error) {
+	blobs := make(map[string]int64)
+
+	rawManifest, err := crane.Manifest(ref, craneOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the manifest for %s: %w", ref, err)
+	}
+
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest for %s: %w", ref, err)
+	}
+
+	// Image indexes don't have layers of their own, their children are removed by garbage collection once untagged
+	if manifest.MediaType.IsIndex() {
+		return blobs, nil
+	}
+
+	blobs[manifest.Config.Digest.String()] = manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		blobs[layer.Digest.String()] = layer.Size
+	}
+
+	return blobs, nil
+}
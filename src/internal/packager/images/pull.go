@@ -12,10 +12,10 @@ import (
 	"io"
 	"os"
 	"path"
-	"path/filepath"
 	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	zarfCache "github.com/defenseunicorns/zarf/src/internal/cache"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/google/go-containerregistry/pkg/crane"
@@ -55,8 +55,7 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to pull image %s: %w", src, err)
 		}
-		imageCachePath := filepath.Join(config.GetAbsCachePath(), config.ZarfImageCacheDir)
-		img = cache.Image(img, cache.NewFilesystemCache(imageCachePath))
+		img = cache.Image(img, cache.NewFilesystemCache(zarfCache.GetImageCachePath()))
 		imageMap[src] = img
 	}
 
@@ -95,6 +94,7 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 		switch {
 		case update.Error != nil && errors.Is(update.Error, io.EOF):
 			progressBar.Success("Pulling %d images (%s)", len(imageMap), utils.ByteFormat(float64(update.Total), 2))
+			i.updateCache(imageMap)
 			return tagToImage, nil
 		case update.Error != nil && strings.HasPrefix(update.Error.Error(), "archive/tar: missed writing "):
 			// Handle potential image cache corruption with a more helpful error. See L#54 in libexec/src/archive/tar/writer.go
@@ -117,6 +117,37 @@ func (i *ImgConfig) PullAll() (map[name.Tag]v1.Image, error) {
 	return tagToImage, nil
 }
 
+// updateCache records the layers of the pulled images in the Zarf cache and enforces the max cache size if one is set
+func (i *ImgConfig) updateCache(imageMap map[string]v1.Image) {
+	for src, img := range imageMap {
+		var layers []string
+
+		if imgLayers, err := img.Layers(); err == nil {
+			for _, layer := range imgLayers {
+				if digest, err := layer.Digest(); err == nil {
+					layers = append(layers, digest.String())
+				}
+				if diffID, err := layer.DiffID(); err == nil {
+					layers = append(layers, diffID.String())
+				}
+			}
+		}
+
+		if err := zarfCache.RecordImageUse(src, layers); err != nil {
+			message.Debugf("Unable to record %s in the image cache index: %s", src, err.Error())
+		}
+	}
+
+	if i.MaxCacheSize > 0 {
+		removed, err := zarfCache.Prune(0, i.MaxCacheSize)
+		if err != nil {
+			message.Warnf("Unable to reduce the cache to %s: %s", utils.ByteFormat(float64(i.MaxCacheSize), 2), err.Error())
+		} else if len(removed) > 0 {
+			message.Debugf("Removed %d entries from the cache to stay under %s", len(removed), utils.ByteFormat(float64(i.MaxCacheSize), 2))
+		}
+	}
+}
+
 func FormatCraneOCILayout(ociPath string) error {
 	type IndexJSON struct {
 		SchemaVersion int `json:"schemaVersion"`
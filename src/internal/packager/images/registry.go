@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// RegistryImage is a tagged image in the registry along with the deployed packages it was shipped by
+type RegistryImage struct {
+	Repository string
+	Tag        string
+	Digest     string
+	Size       int64
+	Source     string
+	Packages   []string
+}
+
+// RegistryImageDetails is the manifest and config of an image in the registry
+type RegistryImageDetails struct {
+	RegistryImage
+	Manifest *v1.Manifest
+	Config   *v1.ConfigFile
+}
+
+// ListRegistryImages returns all tagged images in the registry, optionally filtered to a single repository
+func (i *ImgConfig) ListRegistryImages(deployedImages []DeployedImage, repository string) ([]RegistryImage, error) {
+	message.Debugf("images.ListRegistryImages(%d images, %s)", len(deployedImages), repository)
+
+	registryURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	spinner := message.NewProgressSpinner("Reading the registry catalog")
+	defer spinner.Stop()
+
+	craneOptions := i.registryCraneOptions()
+	origins, err := mapRegistryOrigins(registryURL, deployedImages)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := i.listRegistryManifests(registryURL, craneOptions, spinner)
+	if err != nil {
+		return nil, err
+	}
+
+	var registryImages []RegistryImage
+	for _, manifest := range manifests {
+		if repository != "" && manifest.repository != strings.Trim(repository, "/") {
+			continue
+		}
+
+		var size int64
+		for _, blobSize := range manifest.blobs {
+			size += blobSize
+		}
+
+		for _, tag := range manifest.tags {
+			registryImage := RegistryImage{
+				Repository: manifest.repository,
+				Tag:        tag,
+				Digest:     manifest.digest,
+				Size:       size,
+			}
+			registryImage.addOrigin(origins[fmt.Sprintf("%s:%s", manifest.repository, tag)])
+			registryImage.addOrigin(origins[fmt.Sprintf("%s@%s", manifest.repository, manifest.digest)])
+			registryImages = append(registryImages, registryImage)
+		}
+	}
+
+	spinner.Successf("Found %d images in the registry", len(registryImages))
+	return registryImages, nil
+}
+
+// InspectRegistryImage returns the details of an image in the registry, given either its registry or original name
+func (i *ImgConfig) InspectRegistryImage(deployedImages []DeployedImage, image string) (RegistryImageDetails, error) {
+	message.Debugf("images.InspectRegistryImage(%d images, %s)", len(deployedImages), image)
+
+	var details RegistryImageDetails
+
+	registryURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return details, fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	craneOptions := i.registryCraneOptions()
+	origins, err := mapRegistryOrigins(registryURL, deployedImages)
+	if err != nil {
+		return details, err
+	}
+
+	ref, err := resolveRegistryRef(registryURL, deployedImages, image)
+	if err != nil {
+		return details, err
+	}
+
+	digest, err := crane.Digest(ref, craneOptions...)
+	if err != nil {
+		return details, fmt.Errorf("unable to find %s in the registry: %w", image, err)
+	}
+
+	rawManifest, err := crane.Manifest(ref, craneOptions...)
+	if err != nil {
+		return details, fmt.Errorf("unable to get the manifest for %s: %w", image, err)
+	}
+	if details.Manifest, err = v1.ParseManifest(bytes.NewReader(rawManifest)); err != nil {
+		return details, fmt.Errorf("unable to parse the manifest for %s: %w", image, err)
+	}
+
+	// Indexes don't have a config of their own
+	if !details.Manifest.MediaType.IsIndex() {
+		rawConfig, err := crane.Config(ref, craneOptions...)
+		if err != nil {
+			return details, fmt.Errorf("unable to get the config for %s: %w", image, err)
+		}
+		if details.Config, err = v1.ParseConfigFile(bytes.NewReader(rawConfig)); err != nil {
+			return details, fmt.Errorf("unable to parse the config for %s: %w", image, err)
+		}
+	}
+
+	path := strings.TrimPrefix(ref, registryURL+"/")
+	details.Digest = digest
+	details.Repository, details.Tag = splitRegistryPath(path)
+	details.Size = details.Manifest.Config.Size
+	for _, layer := range details.Manifest.Layers {
+		details.Size += layer.Size
+	}
+	details.addOrigin(origins[path])
+	details.addOrigin(origins[fmt.Sprintf("%s@%s", details.Repository, digest)])
+
+	return details, nil
+}
+
+// DeleteRegistryImage deletes an image manifest from the registry, given either its registry or original name, along with
+// every tag that points to it. Unless forced, it refuses when one of those tags is still used by a deployed package.
+// Note: the registry must still be garbage collected for the blob storage to actually be reclaimed.
+func (i *ImgConfig) DeleteRegistryImage(deployedImages []DeployedImage, image string, force bool) error {
+	message.Debugf("images.DeleteRegistryImage(%d images, %s, %t)", len(deployedImages), image, force)
+
+	registryURL, tunnel, err := i.connectToRegistry()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the Zarf registry: %w", err)
+	}
+	if tunnel != nil {
+		defer tunnel.Close()
+	}
+
+	spinner := message.NewProgressSpinner("Deleting %s from the registry", image)
+	defer spinner.Stop()
+
+	craneOptions := i.registryCraneOptions()
+	ref, err := resolveRegistryRef(registryURL, deployedImages, image)
+	if err != nil {
+		return err
+	}
+
+	// Registries only allow deleting manifests by digest
+	digest, err := crane.Digest(ref, craneOptions...)
+	if err != nil {
+		return fmt.Errorf("unable to find %s in the registry: %w", image, err)
+	}
+
+	repository, _ := splitRegistryPath(strings.TrimPrefix(ref, registryURL+"/"))
+
+	// Deleting the manifest removes every tag that points to it
+	tags, err := crane.ListTags(fmt.Sprintf("%s/%s", registryURL, repository), craneOptions...)
+	if err != nil {
+		return fmt.Errorf("unable to list the tags of %s: %w", repository, err)
+	}
+	var sharedTags []string
+	for _, tag := range tags {
+		tagDigest, err := crane.Digest(fmt.Sprintf("%s/%s:%s", registryURL, repository, tag), craneOptions...)
+		if err != nil {
+			return fmt.Errorf("unable to get the digest of %s:%s: %w", repository, tag, err)
+		}
+		if tagDigest == digest {
+			sharedTags = append(sharedTags, tag)
+		}
+	}
+
+	origins, err := mapRegistryOrigins(registryURL, deployedImages)
+	if err != nil {
+		return err
+	}
+	var inUse []string
+	for _, path := range append([]string{fmt.Sprintf("%s@%s", repository, digest)}, prefixTags(repository, sharedTags)...) {
+		for _, deployedImage := range origins[path] {
+			inUse = append(inUse, fmt.Sprintf("%s (%s in %s)", path, deployedImage.Source, deployedImage.Package))
+		}
+	}
+	if len(inUse) > 0 && !force {
+		return fmt.Errorf("the manifest %s is used by deployed packages through %s, re-run with --force to delete it anyway", digest, strings.Join(inUse, ", "))
+	}
+
+	if len(sharedTags) > 0 {
+		spinner.Updatef("Deleting %s and the tags %s that point to the same manifest", image, strings.Join(sharedTags, ", "))
+	}
+	if err := crane.Delete(fmt.Sprintf("%s/%s@%s", registryURL, repository, digest), craneOptions...); err != nil {
+		return fmt.Errorf("unable to delete %s from the registry: %w", image, err)
+	}
+
+	spinner.Successf("Deleted %s from the registry", image)
+	return nil
+}
+
+// prefixTags converts tags to paths in the registry
+func prefixTags(repository string, tags []string) []string {
+	paths := []string{}
+	for _, tag := range tags {
+		paths = append(paths, fmt.Sprintf("%s:%s", repository, tag))
+	}
+	return paths
+}
+
+// registryCraneOptions returns the crane options needed to read and write to the configured registry
+func (i *ImgConfig) registryCraneOptions() []crane.Option {
+	return append(config.GetCraneOptions(i.Insecure), config.GetCraneAuthOption(i.RegInfo.PushUsername, i.RegInfo.PushPassword))
+}
+
+// addOrigin records the deployed images that map to this registry image
+func (r *RegistryImage) addOrigin(deployedImages []DeployedImage) {
+	for _, image := range deployedImages {
+		r.Source = image.Source
+		r.Packages = append(r.Packages, image.Package)
+	}
+	r.Packages = utils.Unique(r.Packages)
+}
+
+// mapRegistryOrigins maps the path of each deployed image in the registry back to the deployed images it came from
+func mapRegistryOrigins(registryURL string, deployedImages []DeployedImage) (map[string][]DeployedImage, error) {
+	origins := make(map[string][]DeployedImage)
+
+	for _, image := range deployedImages {
+		registryName, err := image.RegistryName(registryURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the registry name for %s: %w", image.Source, err)
+		}
+		path := strings.TrimPrefix(registryName, registryURL+"/")
+		origins[path] = append(origins[path], image)
+	}
+
+	return origins, nil
+}
+
+// resolveRegistryRef converts an original image reference or a path in the registry to a full registry reference
+func resolveRegistryRef(registryURL string, deployedImages []DeployedImage, image string) (string, error) {
+	for _, deployedImage := range deployedImages {
+		if deployedImage.Source == image {
+			return deployedImage.RegistryName(registryURL)
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", registryURL, strings.TrimPrefix(image, "/")), nil
+}
+
+// splitRegistryPath splits a registry path into the repository and its tag or digest
+func splitRegistryPath(path string) (string, string) {
+	if idx := strings.LastIndex(path, "@"); idx > -1 {
+		return path[:idx], path[idx+1:]
+	}
+	if idx := strings.LastIndex(path, ":"); idx > strings.LastIndex(path, "/") {
+		return path[:idx], path[idx+1:]
+	}
+	return path, "latest"
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package template provides functions for templating yaml files
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	goTemplate "text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/types"
+	"sigs.k8s.io/yaml"
+)
+
+// GoTemplateData is the data available to manifests and values files rendered as Go templates
+type GoTemplateData struct {
+	// Variables are the package variables, int and bool variables are converted to their type
+	Variables map[string]any
+	// Constants are the package constants
+	Constants map[string]string
+	// Zarf are the values Zarf provides, e.g. {{ .Zarf.REGISTRY }}
+	Zarf map[string]string
+}
+
+// GoTemplateData returns the variables, constants and builtin values a component's Go templates are rendered with.
+func (values Values) GoTemplateData(component types.ZarfComponent) GoTemplateData {
+	data := GoTemplateData{
+		Variables: make(map[string]any),
+		Constants: make(map[string]string),
+		Zarf:      values.builtins(component),
+	}
+
+	for key, value := range values.config.SetVariableMap {
+		data.Variables[key] = value
+	}
+
+	// Declared variables are typed so they can be used in conditionals and math
+	for _, variable := range values.config.Pkg.Variables {
+		value := values.config.SetVariableMap[variable.Name]
+		switch variable.Type {
+		case config.ZarfVariableTypeInt:
+			if parsed, err := strconv.Atoi(value); err == nil {
+				data.Variables[variable.Name] = parsed
+			}
+		case config.ZarfVariableTypeBool:
+			// Unset bools are false rather than an empty string
+			parsed, _ := strconv.ParseBool(value)
+			data.Variables[variable.Name] = parsed
+		}
+	}
+
+	for _, constant := range values.config.Pkg.Constants {
+		data.Constants[constant.Name] = constant.Value
+	}
+
+	return data
+}
+
+// ExecuteGoTemplate renders text as a Go template with sprig functions, name is used to report errors with their file and line.
+func (values Values) ExecuteGoTemplate(component types.ZarfComponent, name string, text []byte) ([]byte, error) {
+	message.Debugf("template.ExecuteGoTemplate(%s, %s)", component.Name, name)
+
+	if !values.Ready() {
+		return nil, fmt.Errorf("template.ExecuteGoTemplate() called before template.Generate()")
+	}
+
+	tmpl, err := goTemplate.New(name).
+		Funcs(goTemplateFuncs()).
+		Option("missingkey=error").
+		Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the Go template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values.GoTemplateData(component)); err != nil {
+		return nil, fmt.Errorf("unable to render the Go template: %w", err)
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// ApplyGoTemplate renders the file at path as a Go template in place, name is used to report errors with their file and line.
+func (values Values) ApplyGoTemplate(component types.ZarfComponent, path, name string) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", name, err)
+	}
+
+	rendered, err := values.ExecuteGoTemplate(component, name, text)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, rendered, 0600)
+}
+
+// goTemplateFuncs returns the sprig functions along with the YAML helpers helm charts are used to
+func goTemplateFuncs() goTemplate.FuncMap {
+	funcs := sprig.TxtFuncMap()
+
+	funcs["toYaml"] = func(value any) string {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	funcs["fromYaml"] = func(text string) map[string]any {
+		data := map[string]any{}
+		if err := yaml.Unmarshal([]byte(text), &data); err != nil {
+			data["Error"] = err.Error()
+		}
+		return data
+	}
+
+	return funcs
+}
@@ -59,14 +59,8 @@ func (values Values) GetRegistry() string {
 	return values.registry
 }
 
-func (values Values) Apply(component types.ZarfComponent, path string) {
-	message.Debugf("template.Apply(%#v, %s)", component, path)
-
-	if !values.Ready() {
-		// This should only occur if the state couldn't be pulled or on init if a template is attempted before the pre-seed stage
-		message.Fatalf(nil, "template.Apply() called before template.Generate()")
-	}
-
+// builtins returns the values Zarf provides to every template (along with those for specific init components)
+func (values Values) builtins(component types.ZarfComponent) map[string]string {
 	regInfo := values.config.State.RegistryInfo
 	gitInfo := values.config.State.GitServer
 
@@ -107,6 +101,20 @@ func (values Values) Apply(component types.ZarfComponent, path string) {
 		builtinMap["LOGGING_AUTH"] = values.config.State.LoggingSecret
 	}
 
+	return builtinMap
+}
+
+// Apply replaces the Zarf templates in the YAML file at path with the builtin values, variables and constants.
+func (values Values) Apply(component types.ZarfComponent, path string) error {
+	message.Debugf("template.Apply(%#v, %s)", component, path)
+
+	if !values.Ready() {
+		// This should only occur if the state couldn't be pulled or on init if a template is attempted before the pre-seed stage
+		return fmt.Errorf("template.Apply() called before template.Generate()")
+	}
+
+	builtinMap := values.builtins(component)
+
 	// Iterate over any custom variables and add them to the mappings for templating
 	templateMap := map[string]string{}
 	for key, value := range builtinMap {
@@ -124,6 +132,18 @@ func (values Values) Apply(component types.ZarfComponent, path string) {
 		templateMap[strings.ToUpper(fmt.Sprintf("###ZARF_CONST_%s###", constant.Name))] = constant.Value
 	}
 
-	message.Debugf("templateMap = %#v", templateMap)
-	utils.ReplaceTextTemplate(path, templateMap)
+	// Mask sensitive variables in the debug output, the values may be escaped differently by %#v
+	debugMap := make(map[string]string, len(templateMap))
+	for key, value := range templateMap {
+		debugMap[key] = value
+	}
+	for _, variable := range values.config.Pkg.Variables {
+		key := strings.ToUpper(fmt.Sprintf("###ZARF_VAR_%s###", variable.Name))
+		if _, ok := debugMap[key]; ok && variable.IsSensitive() {
+			debugMap[key] = message.RedactedValue
+		}
+	}
+
+	message.Debugf("templateMap = %#v", debugMap)
+	return utils.ReplaceYamlTemplate(path, templateMap)
 }
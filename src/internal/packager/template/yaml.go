@@ -12,14 +12,16 @@ import (
 )
 
 // ProcessYamlFilesInPath iterates over all yaml files in a given path and performs Zarf templating + image swapping
-func ProcessYamlFilesInPath(path string, component types.ZarfComponent, values Values) []string {
+func ProcessYamlFilesInPath(path string, component types.ZarfComponent, values Values) ([]string, error) {
 	// Only pull in yml and yaml files
 	pattern := regexp.MustCompile(`(?mi)\.ya?ml$`)
 	manifests, _ := utils.RecursiveFileList(path, pattern)
 
 	for _, manifest := range manifests {
-		values.Apply(component, manifest)
+		if err := values.Apply(component, manifest); err != nil {
+			return nil, err
+		}
 	}
 
-	return manifests
+	return manifests, nil
 }
@@ -0,0 +1,498 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package validate provides zarf package validation functions
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// pathSegment matches the keys and indexes of a YAML path, e.g. components, [0] and charts in components[0].charts
+var pathSegment = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+
+// readError matches the line and message of an error reading zarf.yaml, e.g. [19:15] cannot unmarshal string into...
+var readError = regexp.MustCompile(`^\[(\d+):\d+\] ([^\n]*)`)
+
+// Finding is a problem found while linting a package and where it was found.
+type Finding struct {
+	// File is the path of the file the problem was found in
+	File string
+	// Line is the line of the problem in the file, 0 if unknown
+	Line int
+	// Path is the YAML path of the problem in zarf.yaml, e.g. components[0].charts[1].version
+	Path string
+	// Message describes the problem
+	Message string
+}
+
+// String returns the finding as file:line: path: message
+func (f Finding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	if f.Path != "" {
+		return fmt.Sprintf("%s: %s: %s", location, f.Path, f.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, f.Message)
+}
+
+type linter struct {
+	baseDir  string
+	file     string
+	root     *yaml.Node
+	findings []Finding
+}
+
+// Lint validates the zarf.yaml in baseDir against the package schema and the package rules and returns every problem found.
+// With confirm, component groups must have a default component as they can't be chosen interactively.
+func Lint(baseDir string, confirm bool) ([]Finding, error) {
+	l := linter{
+		baseDir: baseDir,
+		file:    filepath.Join(baseDir, config.ZarfYAML),
+	}
+
+	text, err := os.ReadFile(l.file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", l.file, err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(text, &document); err != nil {
+		l.findings = append(l.findings, Finding{File: l.file, Message: err.Error()})
+		return l.findings, nil
+	}
+	l.root = &document
+
+	if err := l.lintSchema(text); err != nil {
+		return nil, err
+	}
+
+	// The rules need the package to be readable, the schema findings usually explain why it is not
+	var pkg types.ZarfPackage
+	if err := utils.ReadYaml(l.file, &pkg); err != nil {
+		if len(l.findings) == 0 {
+			finding := Finding{File: l.file, Message: err.Error()}
+			// Keep the line but not the source excerpt of errors like "[19:15] cannot unmarshal..."
+			if match := readError.FindStringSubmatch(err.Error()); match != nil {
+				finding.Line, _ = strconv.Atoi(match[1])
+				finding.Message = match[2]
+			}
+			l.findings = append(l.findings, finding)
+		}
+	} else {
+		l.lintPackage(pkg, confirm)
+		if err := l.lintReferences(pkg); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		// zarf.yaml comes first, then the files it uses
+		if l.findings[i].File != l.findings[j].File {
+			if l.findings[i].File == l.file || l.findings[j].File == l.file {
+				return l.findings[i].File == l.file
+			}
+			return l.findings[i].File < l.findings[j].File
+		}
+		return l.findings[i].Line < l.findings[j].Line
+	})
+
+	return l.findings, nil
+}
+
+// lintSchema validates zarf.yaml against the JSON schema generated from the package types
+func (l *linter) lintSchema(text []byte) error {
+	var schema map[string]any
+	schemaJSON, err := json.Marshal(jsonschema.Reflect(&types.ZarfPackage{}))
+	if err == nil {
+		err = json.Unmarshal(schemaJSON, &schema)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to generate the zarf.yaml schema: %w", err)
+	}
+	dropUnsupportedPatterns(schema)
+
+	document, err := k8syaml.YAMLToJSON(text)
+	if err != nil {
+		l.findings = append(l.findings, Finding{File: l.file, Message: err.Error()})
+		return nil
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewBytesLoader(document))
+	if err != nil {
+		return fmt.Errorf("unable to validate %s against the schema: %w", l.file, err)
+	}
+
+	for _, resultErr := range result.Errors() {
+		var path string
+		if resultErr.Field() != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			for _, part := range strings.Split(resultErr.Field(), ".") {
+				if _, err := strconv.Atoi(part); err == nil {
+					path += "[" + part + "]"
+				} else {
+					path = joinPath(path, part)
+				}
+			}
+		}
+
+		// Point to the unknown key itself rather than its parent
+		if property, ok := resultErr.Details()["property"].(string); ok && resultErr.Type() == "additional_property_not_allowed" {
+			path = joinPath(path, property)
+		}
+
+		l.add(path, resultErr.Description())
+	}
+
+	return nil
+}
+
+// dropUnsupportedPatterns removes the schema patterns Go can't compile (e.g. lookaheads meant for editors), the rules check those values instead
+func dropUnsupportedPatterns(schema any) {
+	switch value := schema.(type) {
+	case map[string]any:
+		if pattern, ok := value["pattern"].(string); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				delete(value, "pattern")
+			}
+		}
+		for _, child := range value {
+			dropUnsupportedPatterns(child)
+		}
+	case []any:
+		for _, child := range value {
+			dropUnsupportedPatterns(child)
+		}
+	}
+}
+
+// lintPackage applies the package rules to each part of the package
+func (l *linter) lintPackage(pkg types.ZarfPackage, confirm bool) {
+	if !l.hasFindings("metadata.name") {
+		l.addErr("metadata.name", validatePackageName(pkg.Metadata.Name))
+	}
+
+	for idx, variable := range pkg.Variables {
+		path := fmt.Sprintf("variables[%d]", idx)
+		if !l.hasFindings(path) {
+			l.addErr(path, validatePackageVariable(variable))
+		}
+	}
+
+	for idx, constant := range pkg.Constants {
+		path := fmt.Sprintf("constants[%d]", idx)
+		if !l.hasFindings(path) {
+			l.addErr(path, validatePackageConstant(constant))
+		}
+	}
+
+	uniqueNames := make(map[string][]string)
+	groups := make(map[string]bool)
+	var groupOrder []string
+	groupPaths := make(map[string]string)
+
+	for idx, component := range pkg.Components {
+		path := fmt.Sprintf("components[%d]", idx)
+
+		// Components for different architectures can share a name, only one of them is used by create
+		arch := component.Only.Cluster.Architecture
+		for _, otherArch := range uniqueNames[component.Name] {
+			if arch == "" || otherArch == "" || arch == otherArch {
+				l.add(path+".name", fmt.Sprintf("component name '%s' is not unique", component.Name))
+				break
+			}
+		}
+		uniqueNames[component.Name] = append(uniqueNames[component.Name], arch)
+
+		if component.Group != "" {
+			if _, ok := groups[component.Group]; !ok {
+				groupOrder = append(groupOrder, component.Group)
+				groupPaths[component.Group] = path + ".group"
+			}
+			groups[component.Group] = groups[component.Group] || component.Default
+		}
+
+		l.addErr(path, validateComponentSelection(component))
+
+		for chartIdx, chart := range component.Charts {
+			chartPath := fmt.Sprintf("%s.charts[%d]", path, chartIdx)
+			if !l.hasFindings(chartPath) {
+				l.addErr(chartPath, validateChart(chart))
+			}
+			l.lintLocalPath(chartPath+".localPath", chart.LocalPath)
+			for valuesIdx, valuesFile := range chart.ValuesFiles {
+				l.lintLocalPath(fmt.Sprintf("%s.valuesFiles[%d]", chartPath, valuesIdx), valuesFile)
+			}
+		}
+
+		for manifestIdx, manifest := range component.Manifests {
+			manifestPath := fmt.Sprintf("%s.manifests[%d]", path, manifestIdx)
+			if !l.hasFindings(manifestPath) {
+				l.addErr(manifestPath, validateManifest(manifest))
+			}
+			for fileIdx, file := range manifest.Files {
+				l.lintLocalPath(fmt.Sprintf("%s.files[%d]", manifestPath, fileIdx), file)
+			}
+			for kustomizationIdx, kustomization := range manifest.Kustomizations {
+				l.lintLocalPath(fmt.Sprintf("%s.kustomizations[%d]", manifestPath, kustomizationIdx), kustomization)
+			}
+		}
+
+		for repoIdx, repo := range component.Repos {
+			l.addErr(fmt.Sprintf("%s.repos[%d]", path, repoIdx), validateRepo(repo))
+		}
+
+		for fileIdx, file := range component.Files {
+			l.lintLocalPath(fmt.Sprintf("%s.files[%d].source", path, fileIdx), file.Source)
+		}
+
+		for injectionIdx, injection := range component.DataInjections {
+			l.lintLocalPath(fmt.Sprintf("%s.dataInjections[%d].source", path, injectionIdx), injection.Source)
+		}
+
+		if len(component.Overrides) > 0 && component.Import.Path == "" && component.Import.URL == "" {
+			l.add(path+".overrides", "overrides can only patch imported components")
+		}
+
+		for overrideIdx, override := range component.Overrides {
+			for chartIdx, chart := range override.Charts {
+				for valuesIdx, valuesFile := range chart.ValuesFiles {
+					l.lintLocalPath(fmt.Sprintf("%s.overrides[%d].charts[%d].valuesFiles[%d]", path, overrideIdx, chartIdx, valuesIdx), valuesFile)
+				}
+			}
+		}
+
+		if strings.Contains(component.Import.Path, "###ZARF_PKG_VAR_") {
+			l.add(path+".import.path", "import paths can't use package variables")
+		} else if strings.Contains(component.Import.URL, "###ZARF_PKG_VAR_") {
+			l.add(path+".import.url", "import urls can't use package variables")
+		} else if component.Import.URL != "" {
+			// Remote imports are only fetched by package create
+			if err := ImportPackage(&component); err != nil {
+				l.add(path+".import.url", err.Error())
+			}
+		} else if component.Import.Path != "" {
+			importedComponent := component
+			importedComponent.Import.Path = filepath.Join(l.baseDir, component.Import.Path)
+			if err := ImportPackage(&importedComponent); err != nil {
+				l.add(path+".import.path", err.Error())
+			}
+		}
+
+		if !l.hasFindings(path + ".scripts") {
+			l.addErr(path+".scripts", validateScripts(component.Scripts))
+		}
+	}
+
+	// Groups are chosen interactively unless they have a default
+	if confirm {
+		for _, group := range groupOrder {
+			if !groups[group] {
+				l.add(groupPaths[group], fmt.Sprintf("group '%s' has no default component, deploying with --confirm requires a component of the group in --components", group))
+			}
+		}
+	}
+}
+
+// lintLocalPath checks that a local path used by the package exists, remote paths and create-time templates are skipped
+func (l *linter) lintLocalPath(path, localPath string) {
+	if localPath == "" || isRemotePath(localPath) || strings.Contains(localPath, "###ZARF_PKG_VAR_") {
+		return
+	}
+
+	if utils.InvalidPath(filepath.Join(l.baseDir, localPath)) {
+		l.add(path, fmt.Sprintf("%s does not exist", localPath))
+	}
+}
+
+// lintReferences checks that the variables and constants used in the local manifests, values files and charts of the package are declared
+func (l *linter) lintReferences(pkg types.ZarfPackage) error {
+	declared := DeclaredTemplates(pkg)
+
+	// Imported packages bring their variables and constants with them
+	var remoteImports []string
+	visited := make(map[string]bool)
+	for _, component := range pkg.Components {
+		if component.Import.URL != "" {
+			remoteImports = append(remoteImports, component.Import.URL)
+		} else if component.Import.Path != "" {
+			remoteImports = append(remoteImports, declareImported(filepath.Join(l.baseDir, component.Import.Path), declared, visited)...)
+		}
+	}
+
+	// These are the files templated during deploy
+	var localPaths []string
+	for _, component := range pkg.Components {
+		for _, chart := range component.Charts {
+			localPaths = append(localPaths, chart.LocalPath)
+			localPaths = append(localPaths, chart.ValuesFiles...)
+		}
+		for _, manifest := range component.Manifests {
+			localPaths = append(localPaths, manifest.Files...)
+			localPaths = append(localPaths, manifest.Kustomizations...)
+		}
+		for _, override := range component.Overrides {
+			for _, chart := range override.Charts {
+				localPaths = append(localPaths, chart.ValuesFiles...)
+			}
+		}
+	}
+
+	checked := make(map[string]bool)
+	for _, localPath := range localPaths {
+		if localPath == "" || isRemotePath(localPath) {
+			continue
+		}
+
+		err := filepath.WalkDir(filepath.Join(l.baseDir, localPath), func(path string, entry fs.DirEntry, err error) error {
+			// Missing paths are already reported
+			if err != nil || entry.IsDir() || checked[path] {
+				return nil
+			}
+			checked[path] = true
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("unable to read %s: %w", path, err)
+			}
+
+			// Go template references are checked when they are rendered
+			for _, reference := range FindTemplateReferences(content) {
+				if reference.GoTemplate || declared[reference.Key] {
+					continue
+				}
+				if len(remoteImports) > 0 {
+					// Remote packages aren't fetched to lint, package create checks the references once they are
+					message.Warnf("%s:%d: %s '%s' is not declared in the package but may be declared by %s",
+						path, reference.Line, reference.Kind(), reference.Name, strings.Join(remoteImports, ", "))
+				} else {
+					l.findings = append(l.findings, Finding{
+						File:    path,
+						Line:    reference.Line,
+						Message: fmt.Sprintf("%s '%s' is used in %s but is not declared in the package", reference.Kind(), reference.Name, reference.Key),
+					})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// declareImported adds the declarations of an imported package and the packages it imports and returns the remote packages they import
+func declareImported(packagePath string, declared, visited map[string]bool) (remoteImports []string) {
+	if visited[packagePath] {
+		return nil
+	}
+	visited[packagePath] = true
+
+	var pkg types.ZarfPackage
+	if err := utils.ReadYaml(filepath.Join(packagePath, config.ZarfYAML), &pkg); err != nil {
+		// Invalid imports are already reported
+		return nil
+	}
+
+	declareTemplates(pkg, declared)
+	for _, component := range pkg.Components {
+		if component.Import.URL != "" {
+			remoteImports = append(remoteImports, component.Import.URL)
+		} else if component.Import.Path != "" {
+			remoteImports = append(remoteImports, declareImported(filepath.Join(packagePath, component.Import.Path), declared, visited)...)
+		}
+	}
+	return remoteImports
+}
+
+// add records a finding at a YAML path of zarf.yaml
+func (l *linter) add(path, message string) {
+	l.findings = append(l.findings, Finding{File: l.file, Line: findLine(l.root, path), Path: path, Message: message})
+}
+
+// addErr records a finding at a YAML path of zarf.yaml if err is not nil
+func (l *linter) addErr(path string, err error) {
+	if err != nil {
+		l.add(path, err.Error())
+	}
+}
+
+// hasFindings returns if there are findings at or below a YAML path, so the rules don't repeat the schema findings
+func (l *linter) hasFindings(path string) bool {
+	for _, finding := range l.findings {
+		if finding.Path == path || strings.HasPrefix(finding.Path, path+".") || strings.HasPrefix(finding.Path, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// findLine returns the line of a YAML path, or of the closest parent that exists
+func findLine(root *yaml.Node, path string) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+
+	for _, segment := range pathSegment.FindAllString(path, -1) {
+		var next *yaml.Node
+
+		if strings.HasPrefix(segment, "[") {
+			idx, _ := strconv.Atoi(strings.Trim(segment, "[]"))
+			if node.Kind == yaml.SequenceNode && idx < len(node.Content) {
+				next = node.Content[idx]
+				line = next.Line
+			}
+		} else if node.Kind == yaml.MappingNode {
+			// Mapping nodes hold their keys and values in turn, the line of the key is where the value starts
+			for idx := 0; idx+1 < len(node.Content); idx += 2 {
+				if node.Content[idx].Value == segment {
+					next = node.Content[idx+1]
+					line = node.Content[idx].Line
+					break
+				}
+			}
+		}
+
+		if next == nil {
+			break
+		}
+		node = next
+	}
+
+	return line
+}
+
+// joinPath adds a key to a YAML path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// isRemotePath returns if a path is a URL or a remote kustomization (e.g. github.com/org/repo//path?ref=v1)
+func isRemotePath(path string) bool {
+	return utils.IsUrl(path) || strings.Contains(path, "//")
+}
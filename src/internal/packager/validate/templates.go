@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package validate provides zarf package validation functions
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/types"
+)
+
+// templateReference matches the deploy-time variables and constants used in package files, e.g. ###ZARF_VAR_DOMAIN###
+var templateReference = regexp.MustCompile(`###ZARF_(VAR|CONST)_([A-Z0-9_]+)###`)
+
+// goTemplateReference matches the variables and constants used in Go templates, e.g. {{ .Variables.DOMAIN }}
+var goTemplateReference = regexp.MustCompile(`\.(Variables|Constants)\.([A-Z0-9_]+)`)
+
+// TemplateReference is a deploy-time variable or constant used in a package file.
+type TemplateReference struct {
+	// Key is the template the reference is replaced by, e.g. ###ZARF_VAR_DOMAIN### (also for Go template references)
+	Key string
+	// Name is the name of the variable or constant, e.g. DOMAIN
+	Name string
+	// Constant is true for references to constants
+	Constant bool
+	// GoTemplate is true for references in Go templates, e.g. {{ .Variables.DOMAIN }}
+	GoTemplate bool
+	// Line is the line of the reference in the file
+	Line int
+}
+
+// Kind returns "variable" or "constant" for messages
+func (r TemplateReference) Kind() string {
+	if r.Constant {
+		return "constant"
+	}
+	return "variable"
+}
+
+// FindTemplateReferences returns the variables and constants used in text, binary files have none.
+func FindTemplateReferences(text []byte) []TemplateReference {
+	var references []TemplateReference
+
+	if bytes.Contains(text, []byte{0}) {
+		return references
+	}
+
+	for idx, line := range strings.Split(string(text), "\n") {
+		for _, match := range templateReference.FindAllStringSubmatch(line, -1) {
+			references = append(references, TemplateReference{
+				Key:      match[0],
+				Name:     match[2],
+				Constant: match[1] == "CONST",
+				Line:     idx + 1,
+			})
+		}
+
+		for _, match := range goTemplateReference.FindAllStringSubmatch(line, -1) {
+			reference := TemplateReference{
+				Key:        fmt.Sprintf("###ZARF_VAR_%s###", match[2]),
+				Name:       match[2],
+				GoTemplate: true,
+				Line:       idx + 1,
+			}
+			if match[1] == "Constants" {
+				reference.Key = fmt.Sprintf("###ZARF_CONST_%s###", match[2])
+				reference.Constant = true
+			}
+			references = append(references, reference)
+		}
+	}
+
+	return references
+}
+
+// DeclaredTemplates returns the template keys of the variables (including those set by scripts) and constants a package declares.
+func DeclaredTemplates(pkg types.ZarfPackage) map[string]bool {
+	declared := make(map[string]bool)
+	declareTemplates(pkg, declared)
+	return declared
+}
+
+// declareTemplates adds the template keys of the variables, script variables and constants of a package to declared
+func declareTemplates(pkg types.ZarfPackage, declared map[string]bool) {
+	for _, variable := range pkg.Variables {
+		declared[fmt.Sprintf("###ZARF_VAR_%s###", variable.Name)] = true
+	}
+	for _, constant := range pkg.Constants {
+		declared[fmt.Sprintf("###ZARF_CONST_%s###", constant.Name)] = true
+	}
+	for _, component := range pkg.Components {
+		for _, script := range append(component.Scripts.Before, component.Scripts.After...) {
+			if script.SetVariable != "" {
+				declared[fmt.Sprintf("###ZARF_VAR_%s###", script.SetVariable)] = true
+			}
+		}
+	}
+}
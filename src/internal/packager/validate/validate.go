@@ -6,14 +6,18 @@ package validate
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/mholt/archiver/v3"
 )
 
 // Run performs config validations
@@ -43,20 +47,75 @@ func Run(pkg types.ZarfPackage) error {
 		}
 		uniqueNames[component.Name] = true
 
-		validateComponent(component)
+		if err := validateComponent(component); err != nil {
+			return fmt.Errorf("invalid component: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// The kinds of sources a component can be imported from
+const (
+	ImportKindLocal   = "local"
+	ImportKindGit     = "git"
+	ImportKindTarball = "tarball"
+	ImportKindOCI     = "oci"
+)
+
+// gitImportRegex matches git urls pinned to a tag or commit, e.g. https://github.com/defenseunicorns/zarf.git@v0.24.0
+var gitImportRegex = regexp.MustCompile(`^[a-z]+:\/\/.+?\/[\w\-\.]+?(\.git)?@[\w\-\.]+$`)
+
+// ImportKind returns where a component import is fetched from, tarballs are recognized by their archive extension.
+func ImportKind(componentImport types.ZarfComponentImport) string {
+	switch {
+	case componentImport.URL == "":
+		return ImportKindLocal
+	case strings.HasPrefix(componentImport.URL, utils.OCIURLPrefix):
+		return ImportKindOCI
+	case isArchiveURL(componentImport.URL):
+		return ImportKindTarball
+	default:
+		return ImportKindGit
+	}
+}
+
+// isArchiveURL returns true if the path of a url ends with an archive extension (e.g. .tar.gz or .zip)
+func isArchiveURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	_, err = archiver.ByExtension(parsedURL.Path)
+	return err == nil
+}
+
 // ImportPackage validates the package trying to be imported.
 func ImportPackage(composedComponent *types.ZarfComponent) error {
 	intro := fmt.Sprintf("imported package %s", composedComponent.Name)
 	path := composedComponent.Import.Path
 
+	if composedComponent.Import.All {
+		if err := importAll(intro, *composedComponent); err != nil {
+			return err
+		}
+	}
+
+	if err := validateOverrides(intro, composedComponent.Overrides); err != nil {
+		return err
+	}
+
+	if composedComponent.Import.URL != "" {
+		return importURL(intro, composedComponent.Import)
+	}
+
 	// ensure path exists
 	if !(len(path) > 0) {
-		return fmt.Errorf("%s must include a path", intro)
+		return fmt.Errorf("%s must include a path or url", intro)
+	}
+
+	if composedComponent.Import.Shasum != "" {
+		return fmt.Errorf("%s can only have a shasum when importing a tarball url", intro)
 	}
 
 	// remove zarf.yaml from path if path has zarf.yaml suffix
@@ -77,6 +136,87 @@ func ImportPackage(composedComponent *types.ZarfComponent) error {
 	return nil
 }
 
+// importURL validates a remote component import, the package itself is checked once it is fetched
+func importURL(intro string, componentImport types.ZarfComponentImport) error {
+	// The path of a remote import is a directory within the fetched source
+	if componentImport.Path != "" {
+		if filepath.IsAbs(componentImport.Path) || strings.HasPrefix(filepath.Clean(componentImport.Path), "..") {
+			return fmt.Errorf("%s path %s must be a directory within the url", intro, componentImport.Path)
+		}
+	}
+
+	switch ImportKind(componentImport) {
+	case ImportKindOCI:
+		if componentImport.Shasum != "" {
+			return fmt.Errorf("%s can only have a shasum when importing a tarball url", intro)
+		}
+		if _, err := name.ParseReference(strings.TrimPrefix(componentImport.URL, utils.OCIURLPrefix)); err != nil {
+			return fmt.Errorf("%s url %s is not a valid OCI reference: %w", intro, componentImport.URL, err)
+		}
+
+	case ImportKindTarball:
+		if !strings.HasPrefix(componentImport.URL, "https://") && !strings.HasPrefix(componentImport.URL, "http://") {
+			return fmt.Errorf("%s url %s must be an http(s) url", intro, componentImport.URL)
+		}
+		if componentImport.Shasum == "" {
+			return fmt.Errorf("%s must include the shasum of the tarball %s", intro, componentImport.URL)
+		}
+
+	default:
+		if componentImport.Shasum != "" {
+			return fmt.Errorf("%s can only have a shasum when importing a tarball url", intro)
+		}
+		if !gitImportRegex.MatchString(componentImport.URL) {
+			return fmt.Errorf("%s url %s must be a git url with a tag or commit (e.g. https://github.com/org/repo.git@v1.0.0), an https tarball or an oci:// reference", intro, componentImport.URL)
+		}
+	}
+
+	return nil
+}
+
+// importAll validates a whole package import, the importing component only names, filters and patches the imported components
+func importAll(intro string, component types.ZarfComponent) error {
+	if component.Import.ComponentName != "" {
+		return fmt.Errorf("%s can't import the component %s by name when importing all components", intro, component.Import.ComponentName)
+	}
+
+	if component.Required || component.Default || component.Group != "" || component.Description != "" {
+		return fmt.Errorf("%s can't set required, default, group or description when importing all components, those of the imported components are used", intro)
+	}
+
+	scripts := component.Scripts
+	if len(component.Charts) > 0 || len(component.Manifests) > 0 || len(component.Images) > 0 || len(component.Files) > 0 ||
+		len(component.Repos) > 0 || len(component.DataInjections) > 0 || component.CosignKeyPath != "" ||
+		len(scripts.Prepare) > 0 || len(scripts.Before) > 0 || len(scripts.After) > 0 {
+		return fmt.Errorf("%s can only change the imported components with overrides when importing all components", intro)
+	}
+
+	return nil
+}
+
+// validateOverrides validates the patches applied to imported components
+func validateOverrides(intro string, overrides []types.ZarfComponentOverride) error {
+	for _, override := range overrides {
+		for _, chart := range override.Charts {
+			if chart.Name == "" {
+				return fmt.Errorf("%s has a chart override without a chart name", intro)
+			}
+
+			switch chart.Patch {
+			case "", "merge", "replace":
+			case "delete":
+				if chart.Version != "" || chart.Namespace != "" || chart.ReleaseName != "" || len(chart.ValuesFiles) > 0 {
+					return fmt.Errorf("%s can't change the chart %s it deletes", intro, chart.Name)
+				}
+			default:
+				return fmt.Errorf("%s has an invalid $patch %s for the chart %s, it must be merge, replace or delete", intro, chart.Patch, chart.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
 func oneIfNotEmpty(testString string) int {
 	if testString == "" {
 		return 0
@@ -86,6 +226,38 @@ func oneIfNotEmpty(testString string) int {
 }
 
 func validateComponent(component types.ZarfComponent) error {
+	if err := validateComponentSelection(component); err != nil {
+		return err
+	}
+
+	// Imports are composed before the package is validated
+	if len(component.Overrides) > 0 {
+		return fmt.Errorf("component %s can only have overrides when it imports a component", component.Name)
+	}
+
+	for _, chart := range component.Charts {
+		if err := validateChart(chart); err != nil {
+			return fmt.Errorf("invalid chart definition: %w", err)
+		}
+	}
+	for _, manifest := range component.Manifests {
+		if err := validateManifest(manifest); err != nil {
+			return fmt.Errorf("invalid manifest definition: %w", err)
+		}
+	}
+	for _, repo := range component.Repos {
+		if err := validateRepo(repo); err != nil {
+			return fmt.Errorf("invalid repo definition: %w", err)
+		}
+	}
+	if err := validateScripts(component.Scripts); err != nil {
+		return fmt.Errorf("invalid scripts in component %s: %w", component.Name, err)
+	}
+
+	return nil
+}
+
+func validateComponentSelection(component types.ZarfComponent) error {
 	if component.Required {
 		if component.Default {
 			return fmt.Errorf("component %s cannot be both required and default", component.Name)
@@ -95,14 +267,24 @@ func validateComponent(component types.ZarfComponent) error {
 		}
 	}
 
-	for _, chart := range component.Charts {
-		if err := validateChart(chart); err != nil {
-			return fmt.Errorf("invalid chart definition: %w", err)
+	return nil
+}
+
+func validateScripts(scripts types.ZarfComponentScripts) error {
+	for _, script := range scripts.Prepare {
+		if script.SetVariable != "" {
+			return fmt.Errorf("prepare script \"%s\" cannot set a variable, only before and after scripts can", script.Cmd)
 		}
 	}
-	for _, manifest := range component.Manifests {
-		if err := validateManifest(manifest); err != nil {
-			return fmt.Errorf("invalid manifest definition: %w", err)
+
+	for _, deployScripts := range [][]types.ZarfScript{scripts.Before, scripts.After} {
+		for _, script := range deployScripts {
+			if script.Cmd == "" {
+				return fmt.Errorf("scripts must have a command")
+			}
+			if script.SetVariable != "" && !regexp.MustCompile(`^[A-Z_]+$`).MatchString(script.SetVariable) {
+				return fmt.Errorf("script \"%s\" variable name '%s' must be all uppercase and contain no special characters except _", script.Cmd, script.SetVariable)
+			}
 		}
 	}
 
@@ -128,6 +310,92 @@ func validatePackageVariable(subject types.ZarfPackageVariable) error {
 		return fmt.Errorf("variable name '%s' must be all uppercase and contain no special characters except _", subject.Name)
 	}
 
+	switch subject.Type {
+	case "", config.ZarfVariableTypeString, config.ZarfVariableTypeInt, config.ZarfVariableTypeBool, config.ZarfVariableTypeFile, config.ZarfVariableTypeMultiline, config.ZarfVariableTypeSecret:
+		if len(subject.Options) > 0 {
+			return fmt.Errorf("variable '%s' can only have options if it is an enum", subject.Name)
+		}
+	case config.ZarfVariableTypeEnum:
+		if len(subject.Options) == 0 {
+			return fmt.Errorf("enum variable '%s' must list its options", subject.Name)
+		}
+	default:
+		return fmt.Errorf("variable '%s' has an unsupported type '%s'", subject.Name, subject.Type)
+	}
+
+	sources := 0
+	for _, source := range []string{subject.From.Env, subject.From.File, subject.From.Secret.Name + subject.From.Secret.Key, subject.From.ConfigMap.Name + subject.From.ConfigMap.Key} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("variable '%s' can only be read from one of env, file, secret or configMap", subject.Name)
+	}
+	for kind, key := range map[string]types.ZarfVariableSourceKey{"secret": subject.From.Secret, "configMap": subject.From.ConfigMap} {
+		if (key.Name != "" || key.Key != "" || key.Namespace != "") && (key.Name == "" || key.Key == "") {
+			return fmt.Errorf("variable '%s' must set both the name and key of the %s to read from", subject.Name, kind)
+		}
+	}
+	if subject.From.File != "" && subject.Type == config.ZarfVariableTypeFile {
+		return fmt.Errorf("file variable '%s' can't be read from a file, set its default to the path instead", subject.Name)
+	}
+
+	if _, err := regexp.Compile(subject.Pattern); err != nil {
+		return fmt.Errorf("variable '%s' has an invalid pattern: %w", subject.Name, err)
+	}
+
+	// The default of a file variable is a path that is only read during deploy
+	if subject.Default != "" && subject.Type != config.ZarfVariableTypeFile {
+		if err := VariableValue(subject, subject.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// VariableValue returns an error if the value does not match the type, options or pattern of the variable.
+func VariableValue(variable types.ZarfPackageVariable, value string) error {
+	if value == "" {
+		if variable.Required {
+			return fmt.Errorf("variable '%s' is required", variable.Name)
+		}
+		return nil
+	}
+
+	// Sensitive values are left out of the errors since they are printed without being redacted
+	got := fmt.Sprintf(", got '%s'", value)
+	if variable.IsSensitive() {
+		got = ""
+	}
+
+	switch variable.Type {
+	case config.ZarfVariableTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("variable '%s' must be an integer%s", variable.Name, got)
+		}
+	case config.ZarfVariableTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("variable '%s' must be true or false%s", variable.Name, got)
+		}
+	case config.ZarfVariableTypeEnum:
+		if !utils.SliceContains(variable.Options, value) {
+			return fmt.Errorf("variable '%s' must be one of %s%s", variable.Name, strings.Join(variable.Options, ", "), got)
+		}
+	case config.ZarfVariableTypeString, config.ZarfVariableTypeSecret:
+		// Untyped variables are left as they were and can still hold certificates or YAML blocks
+		if strings.Contains(value, "\n") {
+			return fmt.Errorf("variable '%s' cannot span multiple lines, use the multiline or file type instead", variable.Name)
+		}
+	}
+
+	if variable.Pattern != "" {
+		if matched, _ := regexp.MatchString(variable.Pattern, value); !matched {
+			return fmt.Errorf("variable '%s' must match the pattern %s", variable.Name, variable.Pattern)
+		}
+	}
+
 	return nil
 }
 
@@ -176,6 +444,15 @@ func validateChart(chart types.ZarfChart) error {
 	return nil
 }
 
+func validateRepo(repo types.ZarfRepo) error {
+	// The git server rejects the packs of shallow repos as they are missing the parents of their oldest commits
+	if repo.Depth > 0 {
+		return fmt.Errorf("repo %s can't set a depth as shallow repos can't be pushed to the git server yet", repo.URL)
+	}
+
+	return nil
+}
+
 func validateManifest(manifest types.ZarfManifest) error {
 	intro := fmt.Sprintf("chart %s", manifest.Name)
 
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package vulns contains functions for matching image SBOMs against an offline vulnerability database
+package vulns
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+
+	// Register the pure-go sqlite driver used to read grype databases
+	_ "modernc.org/sqlite"
+)
+
+// grypeSchemaVersion is the grype database schema this scanner understands
+const grypeSchemaVersion = 5
+
+// database is a read-only grype vulnerability database (vulnerability.db)
+type database struct {
+	db         *sql.DB
+	built      string
+	namespaces []string
+	severities map[string]string
+}
+
+// advisory is a single vulnerable version range for a package in the database
+type advisory struct {
+	id         string
+	namespace  string
+	constraint string
+	format     string
+	fixedIn    []string
+	fixState   string
+}
+
+func openDatabase(path string) (*database, error) {
+	if utils.InvalidPath(path) {
+		return nil, fmt.Errorf("unable to find the vulnerability database %s", path)
+	}
+
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the vulnerability database %s: %w", path, err)
+	}
+
+	d := &database{
+		db:         db,
+		severities: make(map[string]string),
+	}
+
+	var schema int
+	if err := db.QueryRow("SELECT build_timestamp, schema_version FROM id").Scan(&d.built, &schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%s is not a grype vulnerability database: %w", path, err)
+	}
+	if schema != grypeSchemaVersion {
+		_ = db.Close()
+		return nil, fmt.Errorf("unsupported grype database schema v%d, expected v%d", schema, grypeSchemaVersion)
+	}
+
+	rows, err := db.Query("SELECT DISTINCT namespace FROM vulnerability")
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to read the vulnerability namespaces: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var namespace string
+		if err := rows.Scan(&namespace); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		d.namespaces = append(d.namespaces, namespace)
+	}
+	if err := rows.Err(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to read the vulnerability namespaces: %w", err)
+	}
+
+	return d, nil
+}
+
+func (d *database) close() {
+	_ = d.db.Close()
+}
+
+// namespaceFor returns the first namespace in the database ending with one of the given suffixes
+func (d *database) namespaceFor(suffixes ...string) string {
+	for _, suffix := range suffixes {
+		for _, namespace := range d.namespaces {
+			if strings.HasSuffix(namespace, suffix) {
+				return namespace
+			}
+		}
+	}
+	return ""
+}
+
+// advisories returns the vulnerable version ranges recorded for a package in a namespace
+func (d *database) advisories(namespace, packageName string) ([]advisory, error) {
+	rows, err := d.db.Query(`SELECT id, version_constraint, version_format, fixed_in_versions, fix_state
+		FROM vulnerability WHERE namespace = ? AND package_name = ?`, namespace, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query vulnerabilities for %s: %w", packageName, err)
+	}
+	defer rows.Close()
+
+	var advisories []advisory
+	for rows.Next() {
+		var fixedIn sql.NullString
+		a := advisory{namespace: namespace}
+
+		if err := rows.Scan(&a.id, &a.constraint, &a.format, &fixedIn, &a.fixState); err != nil {
+			return nil, err
+		}
+		if fixedIn.Valid && fixedIn.String != "" {
+			_ = json.Unmarshal([]byte(fixedIn.String), &a.fixedIn)
+		}
+
+		advisories = append(advisories, a)
+	}
+
+	return advisories, rows.Err()
+}
+
+// severity returns the severity recorded for a vulnerability in a namespace
+func (d *database) severity(id, namespace string) string {
+	key := namespace + "/" + id
+	if severity, ok := d.severities[key]; ok {
+		return severity
+	}
+
+	severity := SeverityUnknown
+	var recorded sql.NullString
+	err := d.db.QueryRow("SELECT severity FROM vulnerability_metadata WHERE id = ? AND namespace = ?", id, namespace).Scan(&recorded)
+	if err == nil && recorded.Valid && recorded.String != "" {
+		severity = normalizeSeverity(recorded.String)
+	}
+
+	d.severities[key] = severity
+	return severity
+}
@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package vulns contains functions for matching image SBOMs against an offline vulnerability database
+package vulns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/pterm/pterm"
+)
+
+const (
+	SeverityUnknown    = "Unknown"
+	SeverityNegligible = "Negligible"
+	SeverityLow        = "Low"
+	SeverityMedium     = "Medium"
+	SeverityHigh       = "High"
+	SeverityCritical   = "Critical"
+)
+
+var severityRanks = map[string]int{
+	SeverityUnknown:    0,
+	SeverityNegligible: 1,
+	SeverityLow:        2,
+	SeverityMedium:     3,
+	SeverityHigh:       4,
+	SeverityCritical:   5,
+}
+
+// pythonNameSeparatorRegex matches the separators PEP 503 treats alike when comparing python package names
+var pythonNameSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+
+// distroNamespaces maps os-release IDs to the distro names grype uses in its namespaces
+var distroNamespaces = map[string]string{
+	"rhel":      "redhat",
+	"centos":    "redhat",
+	"rocky":     "redhat",
+	"almalinux": "redhat",
+	"amzn":      "amazonlinux",
+	"ol":        "oraclelinux",
+	"sles":      "sles",
+}
+
+// ValidateSeverity returns the canonical name of a severity threshold or an error if it is not known
+func ValidateSeverity(severity string) (string, error) {
+	normalized := normalizeSeverity(severity)
+	if normalized == SeverityUnknown {
+		return "", fmt.Errorf("invalid severity %q, expected one of negligible, low, medium, high or critical", severity)
+	}
+	return normalized, nil
+}
+
+// Scan matches every syft SBOM in sbomDir against the grype vulnerability database at dbPath
+func Scan(sbomDir, dbPath string) (types.VulnerabilityReport, error) {
+	message.Debugf("vulns.Scan(%s, %s)", sbomDir, dbPath)
+
+	report := types.VulnerabilityReport{
+		Timestamp: time.Now().Format(time.RFC1123Z),
+		Matches:   []types.VulnerabilityMatch{},
+	}
+
+	db, err := openDatabase(dbPath)
+	if err != nil {
+		return report, err
+	}
+	defer db.close()
+	report.Database = db.built
+
+	sbomFiles, err := filepath.Glob(filepath.Join(sbomDir, "*.json"))
+	if err != nil {
+		return report, err
+	}
+
+	spinner := message.NewProgressSpinner("Scanning %d images for vulnerabilities", len(sbomFiles))
+	defer spinner.Stop()
+
+	for idx, sbomFile := range sbomFiles {
+		spinner.Updatef("Scanning images for vulnerabilities (%d of %d): %s", idx+1, len(sbomFiles), filepath.Base(sbomFile))
+
+		matches, err := scanSBOM(db, sbomFile)
+		if err != nil {
+			return report, fmt.Errorf("unable to scan %s: %w", filepath.Base(sbomFile), err)
+		}
+		report.Matches = append(report.Matches, matches...)
+	}
+
+	sort.SliceStable(report.Matches, func(i, j int) bool {
+		return severityRanks[report.Matches[i].Severity] > severityRanks[report.Matches[j].Severity]
+	})
+
+	spinner.Successf("Found %d vulnerabilities in %d images", len(report.Matches), len(sbomFiles))
+
+	return report, nil
+}
+
+// CountAtOrAbove returns the number of matches with a severity at or above the threshold
+func CountAtOrAbove(report types.VulnerabilityReport, threshold string) int {
+	var count int
+	for _, match := range report.Matches {
+		if severityRanks[match.Severity] >= severityRanks[normalizeSeverity(threshold)] {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteReport writes a vulnerability report to disk as JSON
+func WriteReport(path string, report types.VulnerabilityReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFile(path, data)
+}
+
+// ReadReport reads a vulnerability report from disk
+func ReadReport(path string) (types.VulnerabilityReport, error) {
+	var report types.VulnerabilityReport
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report, err
+	}
+
+	return report, json.Unmarshal(data, &report)
+}
+
+// PrintReport prints a per-image summary of a vulnerability report and the high and critical findings
+func PrintReport(report types.VulnerabilityReport) {
+	message.Infof("Vulnerability scan from %s (database built %s)", report.Timestamp, report.Database)
+
+	if len(report.Matches) == 0 {
+		message.Info("No vulnerabilities were found in this package")
+		return
+	}
+
+	severities := []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityNegligible, SeverityUnknown}
+	counts := make(map[string]map[string]int)
+	var images []string
+	for _, match := range report.Matches {
+		if counts[match.Image] == nil {
+			counts[match.Image] = make(map[string]int)
+			images = append(images, match.Image)
+		}
+		counts[match.Image][match.Severity]++
+	}
+	sort.Strings(images)
+
+	summary := pterm.TableData{append([]string{"     Image"}, severities...)}
+	for _, image := range images {
+		row := []string{fmt.Sprintf("     %s", image)}
+		for _, severity := range severities {
+			row = append(row, fmt.Sprintf("%d", counts[image][severity]))
+		}
+		summary = append(summary, row)
+	}
+	pterm.Println()
+	_ = pterm.DefaultTable.WithHasHeader().WithData(summary).Render()
+
+	findings := pterm.TableData{{"     Image", "Package", "Version", "Vulnerability", "Severity", "Fixed In"}}
+	for _, match := range report.Matches {
+		if severityRanks[match.Severity] < severityRanks[SeverityHigh] {
+			continue
+		}
+		findings = append(findings, []string{
+			fmt.Sprintf("     %s", match.Image),
+			match.Package,
+			match.Version,
+			match.Vulnerability,
+			match.Severity,
+			strings.Join(match.FixedIn, ", "),
+		})
+	}
+	if len(findings) > 1 {
+		pterm.Println()
+		_ = pterm.DefaultTable.WithHasHeader().WithData(findings).Render()
+	}
+}
+
+// scanSBOM matches the packages in a single syft JSON SBOM against the database
+func scanSBOM(db *database, sbomFile string) ([]types.VulnerabilityMatch, error) {
+	file, err := os.Open(sbomFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	document, _, err := syft.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	image := strings.TrimSuffix(filepath.Base(sbomFile), ".json")
+	if tags := document.Source.ImageMetadata.Tags; len(tags) > 0 {
+		image = tags[0]
+	}
+
+	distroNamespace := db.namespaceFor(distroSuffixes(document.Artifacts.LinuxDistribution)...)
+
+	var matches []types.VulnerabilityMatch
+	for p := range document.Artifacts.PackageCatalog.Enumerate() {
+		namespace := ""
+		if isOSPackage(p) {
+			namespace = distroNamespace
+		} else if p.Language != "" {
+			namespace = db.namespaceFor(fmt.Sprintf(":language:%s", p.Language))
+		}
+		if namespace == "" {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, name := range packageNames(p) {
+			if !isOSPackage(p) {
+				name = languagePackageName(p.Language, name)
+			}
+			advisories, err := db.advisories(namespace, name)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, a := range advisories {
+				if seen[a.id] || !affected(p.Version, a.constraint, a.format) {
+					continue
+				}
+				seen[a.id] = true
+
+				matches = append(matches, types.VulnerabilityMatch{
+					Image:         image,
+					Package:       p.Name,
+					Version:       p.Version,
+					Type:          string(p.Type),
+					Vulnerability: a.id,
+					Severity:      db.severity(a.id, a.namespace),
+					FixedIn:       a.fixedIn,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// distroSuffixes returns the namespace suffixes to try for a distro, from the most to least specific version
+func distroSuffixes(release *linux.Release) []string {
+	if release == nil || release.ID == "" {
+		return nil
+	}
+
+	name := release.ID
+	if mapped, ok := distroNamespaces[name]; ok {
+		name = mapped
+	}
+
+	version := release.VersionID
+	if version == "" {
+		// Rolling distros like wolfi do not set a version
+		return []string{fmt.Sprintf(":distro:%s:rolling", name)}
+	}
+
+	var suffixes []string
+	parts := strings.Split(version, ".")
+	for i := len(parts); i > 0; i-- {
+		suffixes = append(suffixes, fmt.Sprintf(":distro:%s:%s", name, strings.Join(parts[:i], ".")))
+	}
+	return suffixes
+}
+
+func isOSPackage(p pkg.Package) bool {
+	switch p.Type {
+	case pkg.DebPkg, pkg.RpmPkg, pkg.ApkPkg, pkg.AlpmPkg, pkg.PortagePkg:
+		return true
+	}
+	return false
+}
+
+// packageNames returns the package name and the source package name distros record advisories against
+func packageNames(p pkg.Package) []string {
+	names := []string{p.Name}
+
+	switch metadata := p.Metadata.(type) {
+	case pkg.DpkgMetadata:
+		names = append(names, metadata.Source)
+	case pkg.ApkMetadata:
+		names = append(names, metadata.OriginPackage)
+	}
+
+	var unique []string
+	for _, name := range utils.Unique(names) {
+		if name != "" {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// languagePackageName normalizes the name of a language package the way grype records them: in lowercase and, for python,
+// with each run of '-', '_' and '.' as a single '-' (PEP 503)
+func languagePackageName(language pkg.Language, name string) string {
+	name = strings.ToLower(name)
+	if language == pkg.Python {
+		name = pythonNameSeparatorRegex.ReplaceAllString(name, "-")
+	}
+	return name
+}
+
+func normalizeSeverity(severity string) string {
+	for name := range severityRanks {
+		if strings.EqualFold(name, strings.TrimSpace(severity)) {
+			return name
+		}
+	}
+	return SeverityUnknown
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package vulns contains functions for matching image SBOMs against an offline vulnerability database
+package vulns
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var constraintRegex = regexp.MustCompile(`^(<=|>=|==|!=|<|>|=)?\s*(\S+)$`)
+
+// semverPrereleaseRegex matches the release of a semver style version with a pre-release (e.g. 1.2.0- in 1.2.0-rc.1)
+var semverPrereleaseRegex = regexp.MustCompile(`^\d+(\.\d+)*-`)
+
+// prereleaseRegex matches the start of a semver/pep440 style pre-release (e.g. -rc1, .beta2, a1)
+var prereleaseRegex = regexp.MustCompile(`(?i)\d([-.]?)(alpha|beta|rc|pre|dev|a|b)(\d|$)`)
+
+// affected reports whether a version satisfies a grype version constraint (e.g. ">= 1.2, < 1.2.5 || >= 2.0, < 2.0.3").
+// An empty constraint means every version is affected.
+func affected(version, constraint, format string) bool {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" {
+		return true
+	}
+	if constraint == "none" {
+		return false
+	}
+
+	for _, group := range strings.Split(constraint, "||") {
+		if matchesAll(version, group, format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAll(version, group, format string) bool {
+	for _, term := range strings.Split(group, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		matches := constraintRegex.FindStringSubmatch(term)
+		if matches == nil {
+			return false
+		}
+
+		result := compareVersions(version, matches[2], format)
+		switch matches[1] {
+		case "<":
+			if result >= 0 {
+				return false
+			}
+		case "<=":
+			if result > 0 {
+				return false
+			}
+		case ">":
+			if result <= 0 {
+				return false
+			}
+		case ">=":
+			if result < 0 {
+				return false
+			}
+		case "!=":
+			if result == 0 {
+				return false
+			}
+		default:
+			if result != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// compareVersions compares two versions segment by segment in the style of rpmvercmp/dpkg, returning -1, 0 or 1.
+// Epochs are compared first, '~' sorts before everything and non-distro formats treat pre-releases as older than the release.
+func compareVersions(a, b, format string) int {
+	epochA, a := splitEpoch(a)
+	epochB, b := splitEpoch(b)
+	if epochA != epochB {
+		if epochA < epochB {
+			return -1
+		}
+		return 1
+	}
+
+	switch format {
+	case "deb", "dpkg", "rpm", "apk":
+	default:
+		a = markPrerelease(a, format)
+		b = markPrerelease(b, format)
+	}
+
+	return compareSegments(a, b)
+}
+
+func splitEpoch(version string) (int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if idx := strings.Index(version, ":"); idx > 0 {
+		if epoch, err := strconv.Atoi(version[:idx]); err == nil {
+			return epoch, version[idx+1:]
+		}
+	}
+	return 0, version
+}
+
+// markPrerelease rewrites the pre-release separator to '~' so it sorts before the final release
+func markPrerelease(version, format string) string {
+	// Drop semver build metadata, it never affects precedence
+	if idx := strings.Index(version, "+"); idx >= 0 {
+		version = version[:idx]
+	}
+	// Everything after the first '-' is a semver pre-release, but a post-release for python (e.g. 1.0-1)
+	if format != "python" {
+		if loc := semverPrereleaseRegex.FindStringIndex(version); loc != nil {
+			return version[:loc[1]-1] + "~" + version[loc[1]:]
+		}
+	}
+	if loc := prereleaseRegex.FindStringSubmatchIndex(version); loc != nil {
+		// Keep the digit before the pre-release and replace its separator (if any)
+		return version[:loc[2]] + "~" + version[loc[3]:]
+	}
+	return version
+}
+
+func compareSegments(a, b string) int {
+	for a != "" || b != "" {
+		// Skip separators
+		a = strings.TrimLeftFunc(a, isSeparator)
+		b = strings.TrimLeftFunc(b, isSeparator)
+
+		// A tilde sorts before anything, even the end of the version
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		if a == "" || b == "" {
+			break
+		}
+
+		segA, restA, numericA := nextSegment(a)
+		segB, restB, numericB := nextSegment(b)
+
+		// Numeric segments are newer than alphabetic ones
+		if numericA != numericB {
+			if numericA {
+				return 1
+			}
+			return -1
+		}
+
+		if numericA {
+			segA = strings.TrimLeft(segA, "0")
+			segB = strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				if len(segA) < len(segB) {
+					return -1
+				}
+				return 1
+			}
+		}
+
+		if result := strings.Compare(segA, segB); result != 0 {
+			return result
+		}
+
+		a, b = restA, restB
+	}
+
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
+
+func nextSegment(version string) (segment, rest string, numeric bool) {
+	numeric = unicode.IsDigit(rune(version[0]))
+	end := strings.IndexFunc(version, func(r rune) bool {
+		if numeric {
+			return !unicode.IsDigit(r)
+		}
+		return !unicode.IsLetter(r)
+	})
+	if end < 0 {
+		return version, "", numeric
+	}
+	return version[:end], version[end:], numeric
+}
+
+func isSeparator(r rune) bool {
+	return r != '~' && !unicode.IsDigit(r) && !unicode.IsLetter(r)
+}
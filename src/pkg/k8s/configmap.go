@@ -13,6 +13,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// GetConfigmap returns a Kubernetes configmap.
+func (k *K8s) GetConfigmap(namespace, name string) (*corev1.ConfigMap, error) {
+	return k.Clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // ReplaceConfigmap deletes and recreates a configmap
 func (k *K8s) ReplaceConfigmap(namespace, name string, data map[string][]byte) (*corev1.ConfigMap, error) {
 	if err := k.DeleteConfigmap(namespace, name); err != nil {
@@ -71,7 +71,7 @@ func UseLogFile() {
 		Error(err, "Error saving a log file")
 	} else {
 		useLogFile = true
-		logStream := io.MultiWriter(os.Stderr, logFile)
+		logStream := io.MultiWriter(os.Stderr, redactWriter{logFile})
 		pterm.SetDefaultOutput(logStream)
 		message := fmt.Sprintf("Saving log file to %s", logFile.Name())
 		Note(message)
@@ -218,6 +218,10 @@ func paragraph(format string, a ...any) string {
 func debugPrinter(offset int, a ...any) {
 	printer := pterm.Debug.WithShowLineNumber(logLevel > 2).WithLineNumberOffset(offset)
 	now := time.Now().Format(time.RFC3339)
+	// Mask any sensitive values before they are printed
+	for idx, arg := range a {
+		a[idx] = Redact(fmt.Sprint(arg))
+	}
 	// prepend to a
 	a = append([]any{now, " - "}, a...)
 
@@ -229,7 +233,7 @@ func debugPrinter(offset int, a ...any) {
 			WithShowLineNumber(true).
 			WithLineNumberOffset(offset).
 			WithDebugger(false).
-			WithWriter(logFile).
+			WithWriter(redactWriter{logFile}).
 			Println(a...)
 	}
 }
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package message provides a rich set of functions for displaying messages to the user.
+package message
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces sensitive values in debug output and the log file.
+const RedactedValue = "**sensitive**"
+
+var (
+	sensitiveValues []string
+	sensitiveLock   sync.RWMutex
+)
+
+// AddSensitiveValues masks the given values (e.g. passwords) in all debug output and in the log file.
+func AddSensitiveValues(values ...string) {
+	sensitiveLock.Lock()
+	defer sensitiveLock.Unlock()
+
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			sensitiveValues = append(sensitiveValues, value)
+		}
+	}
+}
+
+// Redact replaces any sensitive values in the text with RedactedValue.
+func Redact(text string) string {
+	sensitiveLock.RLock()
+	defer sensitiveLock.RUnlock()
+
+	for _, value := range sensitiveValues {
+		text = strings.ReplaceAll(text, value, RedactedValue)
+	}
+	return text
+}
+
+// redactWriter is an io.Writer that masks sensitive values before writing them.
+type redactWriter struct {
+	writer io.Writer
+}
+
+func (r redactWriter) Write(p []byte) (n int, err error) {
+	if _, err := io.WriteString(r.writer, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -23,6 +23,7 @@ type Packager struct {
 	cluster *cluster.Cluster
 	tmp     types.TempPaths
 	arch    string
+	imports []fetchedImport
 }
 
 /*
@@ -148,6 +149,7 @@ func createPaths() (paths types.TempPaths, err error) {
 		Images:       filepath.Join(basePath, "images.tar"),
 		Components:   filepath.Join(basePath, "components"),
 		Sboms:        filepath.Join(basePath, "sboms"),
+		Vulns:        filepath.Join(basePath, "vulnerabilities.json"),
 		ZarfYaml:     filepath.Join(basePath, config.ZarfYAML),
 	}
 
@@ -21,8 +21,14 @@ func (p *Packager) composeComponents() error {
 
 	components := []types.ZarfComponent{}
 
-	for _, component := range p.cfg.Pkg.Components {
-		if component.Import.Path == "" {
+	// Components importing a whole package become a component for each of its components
+	pkgComponents, err := p.expandPackageImports(p.cfg.Pkg.Components, "")
+	if err != nil {
+		return err
+	}
+
+	for _, component := range pkgComponents {
+		if component.Import.Path == "" && component.Import.URL == "" {
 			components = append(components, component)
 		} else {
 			composedComponent, err := p.getComposedComponent(component)
@@ -63,6 +69,11 @@ func (p *Packager) getComposedComponent(parentComponent types.ZarfComponent) (ch
 		return child, fmt.Errorf("unable to get child component: %w", err)
 	}
 
+	// Patch the child with the overrides of the parent (their paths are relative to the parent)
+	if err := applyComponentOverrides(&child, parentComponent.Overrides); err != nil {
+		return child, fmt.Errorf("unable to override the component imported by %s: %w", parentComponent.Name, err)
+	}
+
 	// Merge the overrides from the child that we just received with the parent we were provided
 	p.mergeComponentOverrides(&child, parentComponent)
 
@@ -72,7 +83,17 @@ func (p *Packager) getComposedComponent(parentComponent types.ZarfComponent) (ch
 func (p *Packager) getChildComponent(parent types.ZarfComponent, pathAncestry string) (child types.ZarfComponent, err error) {
 	message.Debugf("packager.getChildComponent(%+v, %s)", parent, pathAncestry)
 
-	subPkg, err := p.getSubPackage(filepath.Join(pathAncestry, parent.Import.Path))
+	// Remote imports are fetched to a local directory first
+	importPath := filepath.Join(pathAncestry, parent.Import.Path)
+	pathPrefix := parent.Import.Path
+	if parent.Import.URL != "" {
+		if importPath, err = p.fetchImport(parent.Import); err != nil {
+			return child, err
+		}
+		pathPrefix = importPath
+	}
+
+	subPkg, err := p.getSubPackage(importPath)
 	if err != nil {
 		return child, fmt.Errorf("unable to get sub package: %w", err)
 	}
@@ -108,16 +129,24 @@ func (p *Packager) getChildComponent(parent types.ZarfComponent, pathAncestry st
 	}
 
 	// Check if we need to get more of children
-	if child.Import.Path != "" {
-		// Set a temporary composePath so we can get future children/grandchildren from our current location
-		tmpPathAncestry := filepath.Join(pathAncestry, parent.Import.Path)
+	if child.Import.Path != "" || child.Import.URL != "" {
+		// Remote imports are validated before they are fetched, local ones once their path is known
+		if child.Import.URL != "" {
+			if err := validate.ImportPackage(&child); err != nil {
+				return child, fmt.Errorf("invalid import definition in the %s component: %w", child.Name, err)
+			}
+		}
 
-		// Recursively call this function to get the next layer of children
-		grandchildComponent, err := p.getChildComponent(child, tmpPathAncestry)
+		// Recursively call this function to get the next layer of children from our current location
+		grandchildComponent, err := p.getChildComponent(child, importPath)
 		if err != nil {
 			return child, err
 		}
 
+		if err := applyComponentOverrides(&grandchildComponent, child.Overrides); err != nil {
+			return child, fmt.Errorf("unable to override the component imported by %s: %w", child.Name, err)
+		}
+
 		// Merge the grandchild values into the child
 		p.mergeComponentOverrides(&grandchildComponent, child)
 
@@ -126,43 +155,233 @@ func (p *Packager) getChildComponent(parent types.ZarfComponent, pathAncestry st
 	}
 
 	// Fix the filePaths of imported components to be accessible from our current location
-	child = p.fixComposedFilepaths(parent, child)
+	child = p.fixComposedFilepaths(pathPrefix, child, parent.Import.URL != "")
 
 	return
 }
 
-func (p *Packager) fixComposedFilepaths(parent, child types.ZarfComponent) types.ZarfComponent {
-	message.Debugf("packager.fixComposedFilepaths(%+v, %+v)", child, parent)
+func (p *Packager) fixComposedFilepaths(pathPrefix string, child types.ZarfComponent, fetched bool) types.ZarfComponent {
+	message.Debugf("packager.fixComposedFilepaths(%+v, %s, %t)", child, pathPrefix, fetched)
 
 	// Prefix composed component file paths.
 	for fileIdx, file := range child.Files {
-		child.Files[fileIdx].Source = p.getComposedFilePath(file.Source, parent.Import.Path)
+		child.Files[fileIdx].Source = p.getComposedFilePath(file.Source, pathPrefix)
 	}
 
-	// Prefix non-url composed component chart values files.
+	// Prefix non-url composed component chart values files, local chart paths are relative to the package being
+	// created so they are only prefixed for fetched imports (whose charts can only be within what was fetched).
 	for chartIdx, chart := range child.Charts {
+		if fetched && chart.LocalPath != "" {
+			child.Charts[chartIdx].LocalPath = p.getComposedFilePath(chart.LocalPath, pathPrefix)
+		}
 		for valuesIdx, valuesFile := range chart.ValuesFiles {
-			child.Charts[chartIdx].ValuesFiles[valuesIdx] = p.getComposedFilePath(valuesFile, parent.Import.Path)
+			child.Charts[chartIdx].ValuesFiles[valuesIdx] = p.getComposedFilePath(valuesFile, pathPrefix)
 		}
 	}
 
 	// Prefix non-url composed manifest files and kustomizations.
 	for manifestIdx, manifest := range child.Manifests {
 		for fileIdx, file := range manifest.Files {
-			child.Manifests[manifestIdx].Files[fileIdx] = p.getComposedFilePath(file, parent.Import.Path)
+			child.Manifests[manifestIdx].Files[fileIdx] = p.getComposedFilePath(file, pathPrefix)
 		}
 		for kustomIdx, kustomization := range manifest.Kustomizations {
-			child.Manifests[manifestIdx].Kustomizations[kustomIdx] = p.getComposedFilePath(kustomization, parent.Import.Path)
+			child.Manifests[manifestIdx].Kustomizations[kustomIdx] = p.getComposedFilePath(kustomization, pathPrefix)
 		}
 	}
 
 	if child.CosignKeyPath != "" {
-		child.CosignKeyPath = p.getComposedFilePath(child.CosignKeyPath, parent.Import.Path)
+		child.CosignKeyPath = p.getComposedFilePath(child.CosignKeyPath, pathPrefix)
 	}
 
 	return child
 }
 
+// relativeImportPaths returns a copy of the components with the paths into fetched remote imports relative to the
+// imported packages, so the built zarf.yaml doesn't record the cache of the machine that created it
+func (p *Packager) relativeImportPaths(components []types.ZarfComponent) []types.ZarfComponent {
+	recorded := make([]types.ZarfComponent, len(components))
+
+	for idx, component := range components {
+		component.Files = append([]types.ZarfFile{}, component.Files...)
+		for fileIdx, file := range component.Files {
+			component.Files[fileIdx].Source = p.relativeImportPath(file.Source)
+		}
+
+		component.Charts = append([]types.ZarfChart{}, component.Charts...)
+		for chartIdx, chart := range component.Charts {
+			component.Charts[chartIdx].LocalPath = p.relativeImportPath(chart.LocalPath)
+			component.Charts[chartIdx].ValuesFiles = append([]string{}, chart.ValuesFiles...)
+			for valuesIdx, valuesFile := range chart.ValuesFiles {
+				component.Charts[chartIdx].ValuesFiles[valuesIdx] = p.relativeImportPath(valuesFile)
+			}
+		}
+
+		component.Manifests = append([]types.ZarfManifest{}, component.Manifests...)
+		for manifestIdx, manifest := range component.Manifests {
+			component.Manifests[manifestIdx].Files = append([]string{}, manifest.Files...)
+			for fileIdx, file := range manifest.Files {
+				component.Manifests[manifestIdx].Files[fileIdx] = p.relativeImportPath(file)
+			}
+			component.Manifests[manifestIdx].Kustomizations = append([]string{}, manifest.Kustomizations...)
+			for kustomIdx, kustomization := range manifest.Kustomizations {
+				component.Manifests[manifestIdx].Kustomizations[kustomIdx] = p.relativeImportPath(kustomization)
+			}
+		}
+
+		component.CosignKeyPath = p.relativeImportPath(component.CosignKeyPath)
+		recorded[idx] = component
+	}
+
+	return recorded
+}
+
+// expandPackageImports replaces the components importing a whole package with a component importing each of the
+// package's components (for the current architecture), named <name>-<component name>.
+func (p *Packager) expandPackageImports(components []types.ZarfComponent, packagePath string) ([]types.ZarfComponent, error) {
+	message.Debugf("packager.expandPackageImports(%d components, %s)", len(components), packagePath)
+
+	expanded := []types.ZarfComponent{}
+
+	for _, component := range components {
+		if !component.Import.All {
+			expanded = append(expanded, component)
+			continue
+		}
+
+		// Local imports are validated from the package that declares them
+		importPath := filepath.Join(packagePath, component.Import.Path)
+		validated := component
+		if component.Import.URL == "" {
+			validated.Import.Path = importPath
+		}
+		if err := validate.ImportPackage(&validated); err != nil {
+			return nil, fmt.Errorf("invalid import definition in the %s component: %w", component.Name, err)
+		}
+
+		if component.Import.URL != "" {
+			fetchedPath, err := p.fetchImport(component.Import)
+			if err != nil {
+				return nil, fmt.Errorf("unable to import the package for the %s component: %w", component.Name, err)
+			}
+			importPath = fetchedPath
+		}
+
+		subPkg, err := p.getSubPackage(importPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get sub package: %w", err)
+		}
+
+		imported := make(map[string]bool)
+		for _, child := range subPkg.Components {
+			filterArch := child.Only.Cluster.Architecture
+			if component.Only.Cluster.Architecture != "" {
+				filterArch = component.Only.Cluster.Architecture
+			}
+
+			// Components for other architectures (or already imported for this one) are skipped
+			if (filterArch != "" && filterArch != p.arch) || imported[child.Name] {
+				continue
+			}
+			imported[child.Name] = true
+
+			importedComponent := types.ZarfComponent{
+				Name:        fmt.Sprintf("%s-%s", component.Name, child.Name),
+				Description: child.Description,
+				Default:     child.Default,
+				Required:    child.Required,
+				Only:        component.Only,
+				Import:      component.Import,
+			}
+			importedComponent.Import.All = false
+			importedComponent.Import.ComponentName = child.Name
+
+			// Groups are prefixed as well so they stay unique in the importing package
+			if child.Group != "" {
+				importedComponent.Group = fmt.Sprintf("%s-%s", component.Name, child.Group)
+			}
+
+			for _, override := range component.Overrides {
+				if override.Component == "" || override.Component == child.Name {
+					override.Component = ""
+					importedComponent.Overrides = append(importedComponent.Overrides, override)
+				}
+			}
+
+			expanded = append(expanded, importedComponent)
+		}
+
+		if len(imported) == 0 {
+			return nil, fmt.Errorf("the package imported by the %s component has no components for %s", component.Name, p.arch)
+		}
+
+		for _, override := range component.Overrides {
+			if override.Component != "" && !imported[override.Component] {
+				return nil, fmt.Errorf("the override for the component %s doesn't match a component imported by %s", override.Component, component.Name)
+			}
+		}
+	}
+
+	return expanded, nil
+}
+
+// applyComponentOverrides patches an imported component in a strategic-merge style: charts are matched by name
+// (and merged, replaced or deleted), while images, scripts and only filters replace the imported ones.
+func applyComponentOverrides(target *types.ZarfComponent, overrides []types.ZarfComponentOverride) error {
+	for _, override := range overrides {
+		if override.Component != "" && override.Component != target.Name {
+			return fmt.Errorf("the override for the component %s doesn't match the imported component %s", override.Component, target.Name)
+		}
+
+		for _, chartOverride := range override.Charts {
+			chartIdx := -1
+			for idx, chart := range target.Charts {
+				if chart.Name == chartOverride.Name {
+					chartIdx = idx
+					break
+				}
+			}
+			if chartIdx < 0 {
+				return fmt.Errorf("the component %s has no chart %s to override", target.Name, chartOverride.Name)
+			}
+
+			if chartOverride.Patch == "delete" {
+				target.Charts = append(target.Charts[:chartIdx], target.Charts[chartIdx+1:]...)
+				continue
+			}
+
+			chart := &target.Charts[chartIdx]
+			if chartOverride.Version != "" {
+				chart.Version = chartOverride.Version
+			}
+			if chartOverride.Namespace != "" {
+				chart.Namespace = chartOverride.Namespace
+			}
+			if chartOverride.ReleaseName != "" {
+				chart.ReleaseName = chartOverride.ReleaseName
+			}
+
+			// Values files added later take precedence over the imported ones
+			if chartOverride.Patch == "replace" {
+				chart.ValuesFiles = chartOverride.ValuesFiles
+			} else {
+				chart.ValuesFiles = append(chart.ValuesFiles, chartOverride.ValuesFiles...)
+			}
+		}
+
+		if override.Images != nil {
+			target.Images = override.Images
+		}
+		if override.Scripts != nil {
+			target.Scripts = *override.Scripts
+		}
+		if override.Only != nil {
+			target.Only = *override.Only
+		}
+	}
+
+	return nil
+}
+
 // Sets Name, Default, Required and Description to the original components values
 func (p *Packager) mergeComponentOverrides(target *types.ZarfComponent, override types.ZarfComponent) {
 	message.Debugf("packager.mergeComponentOverrides(%+v, %+v)", target, override)
@@ -223,6 +442,11 @@ func (p *Packager) getSubPackage(packagePath string) (importedPackage types.Zarf
 		return importedPackage, err
 	}
 
+	// Whole package imports in the imported package are expanded so their components can be imported by name
+	if importedPackage.Components, err = p.expandPackageImports(importedPackage.Components, packagePath); err != nil {
+		return importedPackage, err
+	}
+
 	// Merge in child package variables (only if the variable does not exist in parent)
 	for _, importedVariable := range importedPackage.Variables {
 		p.injectImportedVariable(importedVariable)
@@ -240,8 +464,8 @@ func (p *Packager) getSubPackage(packagePath string) (importedPackage types.Zarf
 func (p *Packager) getComposedFilePath(originalPath string, pathPrefix string) string {
 	message.Debugf("packager.getComposedFilePath(%s, %s)", originalPath, pathPrefix)
 
-	// Return original if it is a remote file.
-	if utils.IsUrl(originalPath) {
+	// Return original if it is a remote file or already points into a fetched remote import.
+	if utils.IsUrl(originalPath) || filepath.IsAbs(originalPath) {
 		return originalPath
 	}
 
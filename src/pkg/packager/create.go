@@ -15,12 +15,14 @@ import (
 	"time"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cache"
 	"github.com/defenseunicorns/zarf/src/internal/packager/git"
 	"github.com/defenseunicorns/zarf/src/internal/packager/helm"
 	"github.com/defenseunicorns/zarf/src/internal/packager/images"
 	"github.com/defenseunicorns/zarf/src/internal/packager/kustomize"
 	"github.com/defenseunicorns/zarf/src/internal/packager/sbom"
 	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
+	"github.com/defenseunicorns/zarf/src/internal/packager/vulns"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
@@ -66,7 +68,13 @@ func (p *Packager) Create(baseDir string) error {
 	}
 
 	// Perform early package validation
-	validate.Run(p.cfg.Pkg)
+	if err := validate.Run(p.cfg.Pkg); err != nil {
+		return fmt.Errorf("unable to validate package: %w", err)
+	}
+
+	if err := p.validateVulnScan(); err != nil {
+		return err
+	}
 
 	if !p.confirmAction("Create", nil) {
 		return fmt.Errorf("package creation canceled")
@@ -101,6 +109,19 @@ func (p *Packager) Create(baseDir string) error {
 		combinedImageList = append(combinedImageList, component.Images...)
 	}
 
+	// Make sure the packaged manifests, values files and charts only use declared variables and constants
+	if err := p.validatePackageTemplates(); err != nil {
+		return err
+	}
+
+	// Rewrite the zarf.yaml to include the LFS content and chart dependencies recorded while adding components
+	if len(p.cfg.Pkg.Build.GitLFS) > 0 || len(p.cfg.Pkg.Build.ChartDependencies) > 0 {
+		_ = os.Remove(p.tmp.ZarfYaml)
+		if err := p.writeYaml(); err != nil {
+			return fmt.Errorf("unable to write zarf.yaml: %w", err)
+		}
+	}
+
 	// Images are handled separately from other component assets
 	if len(combinedImageList) > 0 {
 		uniqueList := utils.Unique(combinedImageList)
@@ -109,6 +130,13 @@ func (p *Packager) Create(baseDir string) error {
 		}
 	}
 
+	// Scan the image SBOMs before the package is archived so the report ships with it
+	if p.cfg.CreateOpts.VulnDB != "" {
+		if err := p.scanImages(); err != nil {
+			return err
+		}
+	}
+
 	// In case the directory was changed, reset to prevent breaking relative target paths
 	if originalDir != "" {
 		_ = os.Chdir(originalDir)
@@ -120,6 +148,9 @@ func (p *Packager) Create(baseDir string) error {
 	// Try to remove the package if it already exists.
 	_ = os.RemoveAll(packageName)
 
+	// The checkouts of git imports are only needed to add the components
+	_ = os.RemoveAll(p.gitImportsPath())
+
 	// Make the archive
 	archiveSrc := []string{p.tmp.Base + string(os.PathSeparator)}
 	if err := archiver.Archive(archiveSrc, packageName); err != nil {
@@ -141,15 +172,73 @@ func (p *Packager) Create(baseDir string) error {
 	return nil
 }
 
+// validateVulnScan checks the vulnerability scan options before any artifacts are pulled
+func (p *Packager) validateVulnScan() error {
+	if p.cfg.CreateOpts.VulnFailOn != "" {
+		if p.cfg.CreateOpts.VulnDB == "" {
+			return fmt.Errorf("a vulnerability database (--vuln-db) is required to fail on a vulnerability severity")
+		}
+
+		severity, err := vulns.ValidateSeverity(p.cfg.CreateOpts.VulnFailOn)
+		if err != nil {
+			return err
+		}
+		p.cfg.CreateOpts.VulnFailOn = severity
+	}
+
+	if p.cfg.CreateOpts.VulnDB != "" {
+		if p.cfg.CreateOpts.SkipSBOM {
+			return fmt.Errorf("images cannot be scanned for vulnerabilities when SBOM generation is skipped (--skip-sbom)")
+		}
+		if utils.InvalidPath(p.cfg.CreateOpts.VulnDB) {
+			return fmt.Errorf("unable to find the vulnerability database %s", p.cfg.CreateOpts.VulnDB)
+		}
+	}
+
+	return nil
+}
+
+// scanImages matches the image SBOMs against the vulnerability database and adds the report to the package
+func (p *Packager) scanImages() error {
+	report, err := vulns.Scan(p.tmp.Sboms, p.cfg.CreateOpts.VulnDB)
+	if err != nil {
+		return fmt.Errorf("unable to scan the package images for vulnerabilities: %w", err)
+	}
+	report.Threshold = p.cfg.CreateOpts.VulnFailOn
+
+	if err := vulns.WriteReport(p.tmp.Vulns, report); err != nil {
+		return fmt.Errorf("unable to write the vulnerability report: %w", err)
+	}
+
+	if report.Threshold == "" {
+		return nil
+	}
+
+	if count := vulns.CountAtOrAbove(report, report.Threshold); count > 0 {
+		vulns.PrintReport(report)
+		return fmt.Errorf("found %d vulnerabilities at or above %s severity", count, report.Threshold)
+	}
+
+	return nil
+}
+
 func (p *Packager) pullImages(imgList []string, path string) (map[name.Tag]v1.Image, error) {
 	var pulledImages map[name.Tag]v1.Image
+	var maxCacheSize int64
 	var err error
 
+	if p.cfg.CreateOpts.MaxCacheSize != "" {
+		if maxCacheSize, err = cache.ParseSize(p.cfg.CreateOpts.MaxCacheSize); err != nil {
+			return nil, err
+		}
+	}
+
 	return pulledImages, utils.Retry(func() error {
 		imgConfig := images.ImgConfig{
-			TarballPath: path,
-			ImgList:     imgList,
-			Insecure:    p.cfg.CreateOpts.Insecure,
+			TarballPath:  path,
+			ImgList:      imgList,
+			Insecure:     p.cfg.CreateOpts.Insecure,
+			MaxCacheSize: maxCacheSize,
 		}
 
 		pulledImages, err = imgConfig.PullAll()
@@ -194,18 +283,32 @@ func (p *Packager) addComponent(component types.ZarfComponent) error {
 				Cfg:   p.cfg,
 			}
 
+			var tarballPath string
 			if isGitURL {
-				_ = helmCfg.DownloadChartFromGit(componentPath.Charts)
+				tarballPath = helmCfg.DownloadChartFromGit(componentPath.Charts)
 			} else if len(chart.Url) > 0 {
 				helmCfg.DownloadPublishedChart(componentPath.Charts)
+				tarballPath = helm.StandardName(componentPath.Charts, chart) + ".tgz"
 			} else {
-				path := helmCfg.CreateChartFromLocalFiles(componentPath.Charts)
+				tarballPath = helmCfg.CreateChartFromLocalFiles(componentPath.Charts)
 				zarfFilename := fmt.Sprintf("%s-%s.tgz", chart.Name, chart.Version)
-				if !strings.HasSuffix(path, zarfFilename) {
+				if !strings.HasSuffix(tarballPath, zarfFilename) {
 					return fmt.Errorf("error creating chart archive, user provided chart name and/or version does not match given chart")
 				}
 			}
 
+			// Record the vendored dependencies so they show up in package inspect
+			dependencies, err := helm.ChartDependencies(tarballPath)
+			if err != nil {
+				return fmt.Errorf("unable to read the dependencies of chart %s: %w", chart.Name, err)
+			}
+			if len(dependencies) > 0 {
+				if p.cfg.Pkg.Build.ChartDependencies == nil {
+					p.cfg.Pkg.Build.ChartDependencies = make(map[string][]types.ZarfChartDependency)
+				}
+				p.cfg.Pkg.Build.ChartDependencies[component.Name+"/"+chart.Name] = dependencies
+			}
+
 			for idx, path := range chart.ValuesFiles {
 				chartValueName := helm.StandardName(componentPath.Values, chart) + "-" + strconv.Itoa(idx)
 				if err := utils.CreatePathAndCopy(path, chartValueName); err != nil {
@@ -223,7 +326,9 @@ func (p *Packager) addComponent(component types.ZarfComponent) error {
 			destinationFile := filepath.Join(componentPath.Files, strconv.Itoa(index))
 
 			if utils.IsUrl(file.Source) {
-				utils.DownloadToFile(file.Source, destinationFile, component.CosignKeyPath)
+				if err := utils.DownloadToFile(file.Source, destinationFile, component.CosignKeyPath); err != nil {
+					return fmt.Errorf("unable to download file %s: %w", file.Source, err)
+				}
 			} else {
 				if err := utils.CreatePathAndCopy(file.Source, destinationFile); err != nil {
 					return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
@@ -303,11 +408,21 @@ func (p *Packager) addComponent(component types.ZarfComponent) error {
 		spinner := message.NewProgressSpinner("Loading %d git repos", len(component.Repos))
 		defer spinner.Success()
 
-		for _, url := range component.Repos {
-			// Pull all the references if there is no `@` in the string
+		for _, repo := range component.Repos {
+			// Pull all the references if there is no `@` in the string and no branch, tag or depth options
 			gitCfg := git.NewWithSpinner(p.cfg.State.GitServer, spinner)
-			if _, err := gitCfg.Pull(url, componentPath.Repos); err != nil {
-				return fmt.Errorf("unable to pull git repo %s: %w", url, err)
+			path, err := gitCfg.PullRepo(repo, componentPath.Repos)
+			if err != nil {
+				return fmt.Errorf("unable to pull git repo %s: %w", repo.URL, err)
+			}
+
+			// Record the LFS content so it shows up in package inspect
+			if repo.LFS {
+				if p.cfg.Pkg.Build.GitLFS == nil {
+					p.cfg.Pkg.Build.GitLFS = make(map[string]types.ZarfGitLFSData)
+				}
+				objects, size := git.LFSSize(path)
+				p.cfg.Pkg.Build.GitLFS[repo.URL] = types.ZarfGitLFSData{Objects: objects, Size: size}
 			}
 		}
 	}
@@ -71,6 +71,11 @@ func (p *Packager) Deploy() error {
 		utils.RunPreflightChecks()
 	}
 
+	// Split the helm values overrides from the variables before they are used
+	if err := p.setValuesOverrides(); err != nil {
+		return fmt.Errorf("unable to set the helm values overrides: %w", err)
+	}
+
 	spinner.Success()
 
 	// If SBOM files exist, temporary place them in the deploy directory
@@ -121,22 +126,19 @@ func (p *Packager) deployComponents() (deployedComponents []types.DeployedCompon
 	}
 
 	for _, component := range componentsToDeploy {
-		var charts []types.InstalledChart
-
-		deployedComponent := types.DeployedComponent{Name: component.Name}
+		var deployedComponent types.DeployedComponent
 
+		// Deploy the component
 		if p.cfg.IsInitConfig {
-			charts, err = p.deployInitComponent(component)
+			deployedComponent, err = p.deployInitComponent(component)
 		} else {
-			charts, err = p.deployComponent(component, false /* keep img checksum */)
+			deployedComponent, err = p.deployComponent(component, false /* keep img checksum */)
 		}
 
 		if err != nil {
 			return deployedComponents, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
 		}
 
-		// Deploy the component
-		deployedComponent.InstalledCharts = charts
 		deployedComponents = append(deployedComponents, deployedComponent)
 		config.SetDeployingComponents(deployedComponents)
 	}
@@ -145,7 +147,9 @@ func (p *Packager) deployComponents() (deployedComponents []types.DeployedCompon
 	return deployedComponents, nil
 }
 
-func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []types.InstalledChart, err error) {
+func (p *Packager) deployInitComponent(component types.ZarfComponent) (deployed types.DeployedComponent, err error) {
+	deployed.Name = component.Name
+
 	hasExternalRegistry := p.cfg.InitOpts.RegistryInfo.Address != ""
 	isSeedRegistry := component.Name == "zarf-seed-registry"
 	isRegistry := component.Name == "zarf-registry"
@@ -156,14 +160,14 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 	if isSeedRegistry {
 		p.cluster, err = cluster.NewClusterWithWait(5 * time.Minute)
 		if err != nil {
-			return charts, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+			return deployed, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 		}
 		p.cluster.InitZarfState(p.tmp, p.cfg.InitOpts)
 	}
 
 	if hasExternalRegistry && (isSeedRegistry || isInjector || isRegistry) {
 		message.Notef("Not deploying the component (%s) since external registry information was provided during `zarf init`", component.Name)
-		return charts, nil
+		return deployed, nil
 	}
 
 	// Before deploying the seed registry, start the injector
@@ -171,16 +175,16 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 		p.cluster.RunInjectionMadness(p.tmp)
 	}
 
-	charts, err = p.deployComponent(component, isAgent /* skip img checksum if isAgent */)
+	deployed, err = p.deployComponent(component, isAgent /* skip img checksum if isAgent */)
 	if err != nil {
-		return charts, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
+		return deployed, fmt.Errorf("unable to deploy component %s: %w", component.Name, err)
 	}
 
 	// Do cleanup for when we inject the seed registry during initialization
 	if isSeedRegistry {
 		err := p.cluster.PostSeedRegistry(p.tmp)
 		if err != nil {
-			return charts, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
+			return deployed, fmt.Errorf("unable to seed the Zarf Registry: %w", err)
 		}
 
 		seedImage := fmt.Sprintf("%s:%s", config.ZarfSeedImage, config.ZarfSeedTag)
@@ -193,21 +197,23 @@ func (p *Packager) deployInitComponent(component types.ZarfComponent) (charts []
 
 		// Push the seed images into to Zarf registry
 		if err = imgConfig.PushToZarfRegistry(); err != nil {
-			return charts, fmt.Errorf("unable to push the seed images to the Zarf Registry: %w", err)
+			return deployed, fmt.Errorf("unable to push the seed images to the Zarf Registry: %w", err)
 		}
 	}
 
-	return charts, nil
+	return deployed, nil
 }
 
 // Deploy a Zarf Component
-func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum bool) (charts []types.InstalledChart, err error) {
+func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum bool) (deployed types.DeployedComponent, err error) {
+	deployed.Name = component.Name
+
 	message.Debugf("packager.deployComponent(%#v, %#v", p.tmp, component)
 
 	// Toggles for general deploy operations
 	componentPath, err := p.createComponentPaths(component)
 	if err != nil {
-		return charts, fmt.Errorf("unable to create the component paths: %w", err)
+		return deployed, fmt.Errorf("unable to create the component paths: %w", err)
 	}
 
 	// All components now require a name
@@ -221,11 +227,11 @@ func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum
 
 	// Run the 'before' scripts and move files before we do anything else
 	if err = p.runComponentScripts(component.Scripts.Before, component.Scripts); err != nil {
-		return charts, fmt.Errorf("unable to run the 'before' scripts: %w", err)
+		return deployed, fmt.Errorf("unable to run the 'before' scripts: %w", err)
 	}
 
 	if err := p.processComponentFiles(component.Files, componentPath.Files); err != nil {
-		return charts, fmt.Errorf("unable to process the component files: %w", err)
+		return deployed, fmt.Errorf("unable to process the component files: %w", err)
 	}
 
 	if !valueTemplate.Ready() && (hasImages || hasCharts || hasManifests || hasRepos) {
@@ -234,25 +240,31 @@ func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum
 		if p.cluster == nil {
 			p.cluster, err = cluster.NewClusterWithWait(30 * time.Second)
 			if err != nil {
-				return charts, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+				return deployed, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
 			}
 		}
 
 		valueTemplate, err = p.getUpdatedValueTemplate(component)
 		if err != nil {
-			return charts, fmt.Errorf("unable to get the updated value template: %w", err)
+			return deployed, fmt.Errorf("unable to get the updated value template: %w", err)
 		}
 	}
 
 	if hasImages {
 		if err := p.pushImagesToRegistry(component.Images, noImgChecksum); err != nil {
-			return charts, fmt.Errorf("unable to push images to the registry: %w", err)
+			return deployed, fmt.Errorf("unable to push images to the registry: %w", err)
 		}
 	}
 
 	if hasRepos {
-		if err = p.pushReposToRepository(componentPath.Repos, component.Repos); err != nil {
-			return charts, fmt.Errorf("unable to push the repos to the repository: %w", err)
+		if deployed.PushedRepos, err = p.pushReposToRepository(componentPath.Repos, component.Repos); err != nil {
+			return deployed, fmt.Errorf("unable to push the repos to the repository: %w", err)
+		}
+	}
+
+	if hasCharts {
+		if err := p.pushChartsToRegistry(componentPath.Charts, component.Charts); err != nil {
+			return deployed, fmt.Errorf("unable to push helm charts to the registry: %w", err)
 		}
 	}
 
@@ -262,16 +274,22 @@ func (p *Packager) deployComponent(component types.ZarfComponent, noImgChecksum
 		p.performDataInjections(&waitGroup, componentPath, component.DataInjections)
 	}
 
+	if hasCharts {
+		if deployed.ValuesOverrides, err = p.getValuesOverrides(component); err != nil {
+			return deployed, fmt.Errorf("unable to get the helm values overrides: %w", err)
+		}
+	}
+
 	if hasCharts || hasManifests {
-		if charts, err = p.installChartAndManifests(componentPath, component); err != nil {
-			return charts, fmt.Errorf("unable to install helm chart(s): %w", err)
+		if deployed.InstalledCharts, err = p.installChartAndManifests(componentPath, component, deployed.ValuesOverrides); err != nil {
+			return deployed, fmt.Errorf("unable to install helm chart(s): %w", err)
 		}
 	}
 
 	// Run the 'after' scripts after all other attributes of the component has been deployed
 	p.runComponentScripts(component.Scripts.After, component.Scripts)
 
-	return charts, nil
+	return deployed, nil
 }
 
 // Move files onto the host of the machine performing the deployment
@@ -378,9 +396,34 @@ func (p *Packager) pushImagesToRegistry(componentImages []string, noImgChecksum
 	}, 3, 5*time.Second)
 }
 
+// Push the components helm charts that are marked for it to the configured container registry as OCI artifacts
+func (p *Packager) pushChartsToRegistry(chartsPath string, charts []types.ZarfChart) error {
+	imgConfig := images.ImgConfig{
+		RegInfo: p.cfg.State.RegistryInfo,
+	}
+
+	for _, chart := range charts {
+		if !chart.PushToRegistry {
+			continue
+		}
+
+		tarballPath := helm.StandardName(chartsPath, chart) + ".tgz"
+		err := utils.Retry(func() error {
+			return imgConfig.PushChartToZarfRegistry(chart, tarballPath)
+		}, 3, 5*time.Second)
+		if err != nil {
+			return fmt.Errorf("unable to push chart %s to the registry: %w", chart.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // Push all of the components git repos to the configured git server
-func (p *Packager) pushReposToRepository(reposPath string, repos []string) error {
-	for _, repoURL := range repos {
+func (p *Packager) pushReposToRepository(reposPath string, repos []types.ZarfRepo) (pushedRepos []types.PushedRepo, err error) {
+	for _, repo := range repos {
+		repoURL := repo.URL
+		var pushed types.PushedRepo
 
 		// Create an anonymous function to push the repo to the Zarf git server
 		tryPush := func() error {
@@ -401,17 +444,21 @@ func (p *Packager) pushReposToRepository(reposPath string, repos []string) error
 			if repoPath, err := gitClient.TransformURLtoRepoName(repoURL); err != nil {
 				return fmt.Errorf("unable to get the repo name from the URL %s: %w", repoURL, err)
 			} else {
-				return gitClient.PushRepo(filepath.Join(reposPath, repoPath))
+				pushed, err = gitClient.PushRepo(filepath.Join(reposPath, repoPath))
+				return err
 			}
 		}
 
 		// Try repo push up to 3 times
 		if err := utils.Retry(tryPush, 3, 5*time.Second); err != nil {
-			return fmt.Errorf("unable to push repo %s to the Git Server: %w", repoURL, err)
+			return pushedRepos, fmt.Errorf("unable to push repo %s to the Git Server: %w", repoURL, err)
 		}
+
+		// Record what was pushed so changes made in the git server can be exported later
+		pushedRepos = append(pushedRepos, pushed)
 	}
 
-	return nil
+	return pushedRepos, nil
 }
 
 // Async'ly move data into a container running in a pod on the k8s cluster
@@ -427,23 +474,31 @@ func (p *Packager) performDataInjections(waitGroup *sync.WaitGroup, componentPat
 }
 
 // Install all Helm charts and raw k8s manifests into the k8s cluster
-func (p *Packager) installChartAndManifests(componentPath types.ComponentPaths, component types.ZarfComponent) ([]types.InstalledChart, error) {
+func (p *Packager) installChartAndManifests(componentPath types.ComponentPaths, component types.ZarfComponent, valuesOverrides map[string]map[string]any) ([]types.InstalledChart, error) {
 	installedCharts := []types.InstalledChart{}
 
 	for _, chart := range component.Charts {
 		// zarf magic for the value file
-		for idx := range chart.ValuesFiles {
+		for idx, valuesFile := range chart.ValuesFiles {
 			chartValueName := helm.StandardName(componentPath.Values, chart) + "-" + strconv.Itoa(idx)
-			valueTemplate.Apply(component, chartValueName)
+			if chart.GoTemplate {
+				if err := valueTemplate.ApplyGoTemplate(component, chartValueName, valuesFile); err != nil {
+					return installedCharts, fmt.Errorf("unable to template the values file %s of chart %s: %w", valuesFile, chart.Name, err)
+				}
+			}
+			if err := valueTemplate.Apply(component, chartValueName); err != nil {
+				return installedCharts, fmt.Errorf("unable to template the values file %s of chart %s: %w", valuesFile, chart.Name, err)
+			}
 		}
 
 		// Generate helm templates to pass to gitops engine
 		helmCfg := &helm.Helm{
-			BasePath:  componentPath.Base,
-			Chart:     chart,
-			Component: component,
-			Cfg:       p.cfg,
-			Cluster:   p.cluster,
+			BasePath:        componentPath.Base,
+			Chart:           chart,
+			Component:       component,
+			Cfg:             p.cfg,
+			Cluster:         p.cluster,
+			ValuesOverrides: valuesOverrides[chart.Name],
 		}
 
 		addedConnectStrings, installedChartName, err := helmCfg.InstallOrUpgradeChart()
@@ -477,7 +532,7 @@ func (p *Packager) installChartAndManifests(componentPath types.ComponentPaths,
 			Cfg:       p.cfg,
 			Cluster:   p.cluster,
 		}
-		addedConnectStrings, installedChartName, err := helmCfg.GenerateChart(manifest)
+		addedConnectStrings, installedChartName, err := helmCfg.GenerateChart(manifest, valueTemplate)
 		if err != nil {
 			return installedCharts, err
 		}
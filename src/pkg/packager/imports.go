@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying zarf packages
+package packager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cache"
+	"github.com/defenseunicorns/zarf/src/internal/packager/git"
+	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/mholt/archiver/v3"
+)
+
+// fetchedImport is where a remote import was fetched to: the root of the repository, archive or image and the
+// directory of the imported package in it
+type fetchedImport struct {
+	sourcePath  string
+	packagePath string
+}
+
+// fetchImport fetches the remote package of a component import (or reuses the cached copy), records what the url
+// resolved to in the build data and returns the local directory to import from.
+func (p *Packager) fetchImport(componentImport types.ZarfComponentImport) (string, error) {
+	message.Debugf("packager.fetchImport(%+v)", componentImport)
+
+	var sourcePath string
+	var source types.ZarfImportSource
+	var err error
+
+	switch validate.ImportKind(componentImport) {
+	case validate.ImportKindOCI:
+		sourcePath, source, err = p.fetchOCIImport(componentImport.URL)
+	case validate.ImportKindTarball:
+		sourcePath, source, err = fetchTarballImport(componentImport.URL, componentImport.Shasum)
+	default:
+		sourcePath, source, err = p.fetchGitImport(componentImport.URL)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch %s: %w", componentImport.URL, err)
+	}
+
+	packagePath := filepath.Join(sourcePath, strings.TrimSuffix(componentImport.Path, config.ZarfYAML))
+	if utils.InvalidPath(filepath.Join(packagePath, config.ZarfYAML)) {
+		return "", fmt.Errorf("%s does not contain a zarf.yaml in the directory \"%s\"", componentImport.URL, componentImport.Path)
+	}
+
+	if p.cfg.Pkg.Build.ComponentImports == nil {
+		p.cfg.Pkg.Build.ComponentImports = make(map[string]types.ZarfImportSource)
+	}
+	p.cfg.Pkg.Build.ComponentImports[componentImport.URL] = source
+	p.imports = append(p.imports, fetchedImport{sourcePath: sourcePath, packagePath: packagePath})
+
+	return packagePath, nil
+}
+
+// fetchGitImport checks out the tag or commit of a git url, the repository is cached with the other git repos
+func (p *Packager) fetchGitImport(gitURL string) (string, types.ZarfImportSource, error) {
+	var source types.ZarfImportSource
+
+	spinner := message.NewProgressSpinner("Fetching the component import %s", gitURL)
+	defer spinner.Stop()
+
+	gitCfg := git.NewWithSpinner(p.cfg.State.GitServer, spinner)
+	repoName, err := gitCfg.TransformURLtoRepoName(gitURL)
+	if err != nil {
+		return "", source, err
+	}
+
+	// Checkouts are made in the temp directory and shared by the components that import the same url
+	importsPath := filepath.Join(p.gitImportsPath(), fmt.Sprintf("%x", sha256.Sum256([]byte(gitURL))))
+	repoPath := filepath.Join(importsPath, repoName)
+	if utils.InvalidPath(repoPath) {
+		if repoPath, err = gitCfg.Pull(gitURL, importsPath); err != nil {
+			return "", source, err
+		}
+	}
+	gitCfg.GitPath = repoPath
+
+	commit, err := gitCfg.HeadCommit()
+	if err != nil {
+		return "", source, err
+	}
+
+	source.Resolved = fmt.Sprintf("%s@%s", gitURL[:strings.LastIndex(gitURL, "@")], commit)
+	source.Digest = commit
+
+	spinner.Successf("Fetched the component import %s (%s)", gitURL, commit)
+
+	return repoPath, source, nil
+}
+
+// fetchTarballImport downloads and extracts a tarball into the component cache, keyed by its checksum
+func fetchTarballImport(tarballURL, shasum string) (string, types.ZarfImportSource, error) {
+	shasum = strings.ToLower(shasum)
+	source := types.ZarfImportSource{
+		Resolved: tarballURL,
+		Digest:   "sha256:" + shasum,
+	}
+
+	cachePath := filepath.Join(cache.GetComponentCachePath(), "sha256-"+shasum)
+	if !utils.InvalidPath(cachePath) {
+		cache.MarkComponentUsed(cachePath)
+		return archiveRoot(cachePath), source, nil
+	}
+
+	spinner := message.NewProgressSpinner("Fetching the component import %s", tarballURL)
+	defer spinner.Stop()
+
+	parsedURL, err := url.Parse(tarballURL)
+	if err != nil {
+		return "", source, err
+	}
+
+	tmpPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return "", source, err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	archivePath := filepath.Join(tmpPath, path.Base(parsedURL.Path))
+	if err := utils.DownloadToFile(tarballURL, archivePath, ""); err != nil {
+		return "", source, err
+	}
+
+	actualShasum, err := utils.GetSha256Sum(archivePath)
+	if err != nil {
+		return "", source, fmt.Errorf("unable to compute the shasum of %s: %w", tarballURL, err)
+	}
+	if actualShasum != shasum {
+		return "", source, fmt.Errorf("shasum mismatch for %s: expected %s, got %s", tarballURL, shasum, actualShasum)
+	}
+
+	if err := extractToCache(cachePath, func(target string) error {
+		return archiver.Unarchive(archivePath, target)
+	}); err != nil {
+		return "", source, err
+	}
+
+	spinner.Successf("Fetched the component import %s", tarballURL)
+
+	return archiveRoot(cachePath), source, nil
+}
+
+// fetchOCIImport pulls the filesystem of an OCI image into the component cache, keyed by its digest
+func (p *Packager) fetchOCIImport(ociURL string) (string, types.ZarfImportSource, error) {
+	var source types.ZarfImportSource
+
+	spinner := message.NewProgressSpinner("Fetching the component import %s", ociURL)
+	defer spinner.Stop()
+
+	ref, err := name.ParseReference(strings.TrimPrefix(ociURL, utils.OCIURLPrefix))
+	if err != nil {
+		return "", source, err
+	}
+
+	options := config.GetCraneOptions(p.cfg.CreateOpts.Insecure)
+	digest, err := crane.Digest(ref.String(), options...)
+	if err != nil {
+		return "", source, fmt.Errorf("unable to resolve the digest: %w", err)
+	}
+
+	pinned := ref.Context().Digest(digest)
+	source.Resolved = utils.OCIURLPrefix + pinned.String()
+	source.Digest = digest
+
+	cachePath := filepath.Join(cache.GetComponentCachePath(), strings.Replace(digest, ":", "-", 1))
+	if !utils.InvalidPath(cachePath) {
+		cache.MarkComponentUsed(cachePath)
+		spinner.Successf("Using the cached component import %s (%s)", ociURL, digest)
+		return cachePath, source, nil
+	}
+
+	img, err := crane.Pull(pinned.String(), options...)
+	if err != nil {
+		return "", source, fmt.Errorf("unable to pull the image: %w", err)
+	}
+
+	tmpPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return "", source, err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	// Flatten the image layers into a single tarball of its filesystem
+	layersPath := filepath.Join(tmpPath, "filesystem.tar")
+	layersFile, err := os.Create(layersPath)
+	if err != nil {
+		return "", source, err
+	}
+	if err := crane.Export(img, layersFile); err != nil {
+		layersFile.Close()
+		return "", source, fmt.Errorf("unable to export the image filesystem: %w", err)
+	}
+	layersFile.Close()
+
+	if err := extractToCache(cachePath, func(target string) error {
+		return archiver.Unarchive(layersPath, target)
+	}); err != nil {
+		return "", source, err
+	}
+
+	spinner.Successf("Fetched the component import %s (%s)", ociURL, digest)
+
+	return cachePath, source, nil
+}
+
+// gitImportsPath returns where git imports are checked out, it is removed before the package is archived
+func (p *Packager) gitImportsPath() string {
+	return filepath.Join(p.tmp.Base, "imports")
+}
+
+// relativeImportPath returns a path into a fetched remote import relative to the imported package, as it is written
+// in the imported zarf.yaml, other paths are returned as they are
+func (p *Packager) relativeImportPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	for _, fetched := range p.imports {
+		if fromSource, err := filepath.Rel(fetched.sourcePath, path); err != nil || strings.HasPrefix(fromSource, "..") {
+			continue
+		}
+		if relativePath, err := filepath.Rel(fetched.packagePath, path); err == nil {
+			return relativePath
+		}
+	}
+
+	return path
+}
+
+// extractToCache extracts into a partial directory next to cachePath and moves it into place once complete,
+// so an interrupted fetch is never used as a cached import
+func extractToCache(cachePath string, extract func(target string) error) error {
+	partialPath := cachePath + ".partial"
+	_ = os.RemoveAll(partialPath)
+
+	if err := utils.CreateDirectory(filepath.Dir(cachePath), 0700); err != nil {
+		return fmt.Errorf("unable to create the component cache directory: %w", err)
+	}
+
+	if err := extract(partialPath); err != nil {
+		_ = os.RemoveAll(partialPath)
+		return fmt.Errorf("unable to extract the import: %w", err)
+	}
+
+	return os.Rename(partialPath, cachePath)
+}
+
+// archiveRoot returns the single top-level directory of an extracted archive (i.e. GitHub release archives), or the
+// extracted directory itself
+func archiveRoot(extractedPath string) string {
+	entries, err := os.ReadDir(extractedPath)
+	if err == nil && len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(extractedPath, entries[0].Name())
+	}
+	return extractedPath
+}
@@ -7,12 +7,15 @@ package packager
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/defenseunicorns/zarf/src/config"
 	"github.com/defenseunicorns/zarf/src/internal/packager/sbom"
+	"github.com/defenseunicorns/zarf/src/internal/packager/vulns"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/mholt/archiver/v3"
+	"github.com/pterm/pterm"
 )
 
 // Inspect list the contents of a package
@@ -34,6 +37,78 @@ func (p *Packager) Inspect(packageName string, includeSBOM bool, outputSBOM stri
 	message.Infof("The package was built with Zarf CLI version %s\n", p.cfg.Pkg.Build.Version)
 	utils.ColorPrintYAML(p.cfg.Pkg)
 
+	if len(p.cfg.Pkg.Build.GitLFS) > 0 {
+		var repos []string
+		for repo := range p.cfg.Pkg.Build.GitLFS {
+			repos = append(repos, repo)
+		}
+		sort.Strings(repos)
+
+		list := pterm.TableData{{"     Repo", "LFS Objects", "LFS Size"}}
+		for _, repo := range repos {
+			lfs := p.cfg.Pkg.Build.GitLFS[repo]
+			list = append(list, pterm.TableData{{
+				fmt.Sprintf("     %s", repo),
+				fmt.Sprintf("%d", lfs.Objects),
+				utils.ByteFormat(float64(lfs.Size), 2),
+			}}...)
+		}
+		pterm.Println()
+		_ = pterm.DefaultTable.WithHasHeader().WithData(list).Render()
+	}
+
+	if len(p.cfg.Pkg.Build.ChartDependencies) > 0 {
+		var charts []string
+		for chart := range p.cfg.Pkg.Build.ChartDependencies {
+			charts = append(charts, chart)
+		}
+		sort.Strings(charts)
+
+		list := pterm.TableData{{"     Chart", "Dependency", "Version", "Repository"}}
+		for _, chart := range charts {
+			for _, dependency := range p.cfg.Pkg.Build.ChartDependencies[chart] {
+				list = append(list, pterm.TableData{{
+					fmt.Sprintf("     %s", chart),
+					dependency.Name,
+					dependency.Version,
+					dependency.Repository,
+				}}...)
+			}
+		}
+		pterm.Println()
+		_ = pterm.DefaultTable.WithHasHeader().WithData(list).Render()
+	}
+
+	if len(p.cfg.Pkg.Build.ComponentImports) > 0 {
+		var urls []string
+		for url := range p.cfg.Pkg.Build.ComponentImports {
+			urls = append(urls, url)
+		}
+		sort.Strings(urls)
+
+		list := pterm.TableData{{"     Import", "Resolved", "Digest"}}
+		for _, url := range urls {
+			source := p.cfg.Pkg.Build.ComponentImports[url]
+			list = append(list, pterm.TableData{{
+				fmt.Sprintf("     %s", url),
+				source.Resolved,
+				source.Digest,
+			}}...)
+		}
+		pterm.Println()
+		_ = pterm.DefaultTable.WithHasHeader().WithData(list).Render()
+	}
+
+	// Show the vulnerability report if the images were scanned during create (extracting a missing file is not an error)
+	_ = archiver.Extract(packageName, filepath.Base(p.tmp.Vulns), p.tmp.Base)
+	if !utils.InvalidPath(p.tmp.Vulns) {
+		report, err := vulns.ReadReport(p.tmp.Vulns)
+		if err != nil {
+			return fmt.Errorf("unable to read the vulnerability report: %w", err)
+		}
+		vulns.PrintReport(report)
+	}
+
 	if includeSBOM || outputSBOM != "" {
 		err := archiver.Extract(packageName, "sboms", p.tmp.Base)
 		if err != nil {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
@@ -56,14 +57,69 @@ func (p *Packager) promptVariable(variable types.ZarfPackageVariable) (value str
 		message.Question(variable.Description)
 	}
 
-	prompt := &survey.Input{
-		Message: fmt.Sprintf("Please provide a value for \"%s\"", variable.Name),
-		Default: variable.Default,
+	promptMessage := fmt.Sprintf("Please provide a value for \"%s\"", variable.Name)
+
+	var prompt survey.Prompt
+	switch variable.Type {
+	case config.ZarfVariableTypeEnum:
+		prompt = &survey.Select{
+			Message: promptMessage,
+			Options: variable.Options,
+			Default: variable.Default,
+		}
+	case config.ZarfVariableTypeBool:
+		prompt = &survey.Select{
+			Message: promptMessage,
+			Options: []string{"true", "false"},
+			Default: variable.Default,
+		}
+	case config.ZarfVariableTypeMultiline:
+		prompt = &survey.Multiline{
+			Message: promptMessage,
+			Default: variable.Default,
+		}
+	case config.ZarfVariableTypeSecret:
+		prompt = &survey.Password{
+			Message: promptMessage,
+		}
+	case config.ZarfVariableTypeFile:
+		prompt = &survey.Input{
+			Message: fmt.Sprintf("Please provide the path to a file for \"%s\"", variable.Name),
+			Default: variable.Default,
+		}
+	default:
+		prompt = &survey.Input{
+			Message: promptMessage,
+			Default: variable.Default,
+		}
 	}
 
-	if err = survey.AskOne(prompt, &value); err != nil {
+	// Hide the input of sensitive variables that would otherwise be echoed
+	if _, isInput := prompt.(*survey.Input); isInput && variable.IsSensitive() && variable.Type != config.ZarfVariableTypeFile {
+		prompt = &survey.Password{
+			Message: promptMessage,
+		}
+	}
+
+	// Ask again until the value is valid, file variables are checked once the file is read
+	validator := func(answer interface{}) error {
+		if variable.Type == config.ZarfVariableTypeFile {
+			return nil
+		}
+		if option, ok := answer.(survey.OptionAnswer); ok {
+			return validate.VariableValue(variable, option.Value)
+		}
+		return validate.VariableValue(variable, fmt.Sprint(answer))
+	}
+
+	if err = survey.AskOne(prompt, &value, survey.WithValidator(validator)); err != nil {
 		return "", err
 	}
 
+	// Password prompts can't show a default, an empty answer keeps it
+	if value == "" {
+		value = variable.Default
+	}
+
 	return value, nil
 }
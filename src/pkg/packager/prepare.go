@@ -70,9 +70,13 @@ func (p *Packager) FindImages(baseDir, repoHelmChartPath string) error {
 		if repoHelmChartPath != "" {
 			// Also process git repos that have helm charts
 			for _, repo := range component.Repos {
-				matches := strings.Split(repo, "@")
-				if len(matches) < 2 {
-					message.Warnf("Cannot convert git repo %s to helm chart without a version tag", repo)
+				// Split on the last @ so ssh URLs (git@host:path) keep their user
+				url, version := repo.URL, ""
+				if atIdx := strings.LastIndex(repo.URL, "@"); atIdx >= 0 {
+					url, version = repo.URL[:atIdx], repo.URL[atIdx+1:]
+				}
+				if version == "" || strings.ContainsAny(version, ":/") {
+					message.Warnf("Cannot convert git repo %s to helm chart without a version tag", repo.URL)
 					continue
 				}
 
@@ -81,9 +85,9 @@ func (p *Packager) FindImages(baseDir, repoHelmChartPath string) error {
 
 				// If a repo helmchartpath is specified,
 				component.Charts = append(component.Charts, types.ZarfChart{
-					Name:    repo,
-					Url:     matches[0],
-					Version: matches[1],
+					Name:    repo.URL,
+					Url:     url,
+					Version: version,
 					GitPath: repoHelmChartPath,
 				})
 			}
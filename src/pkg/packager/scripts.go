@@ -13,13 +13,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
 	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
 )
 
 // Run scripts that a component has provided
-func (p *Packager) runComponentScripts(scripts []string, componentScript types.ZarfComponentScripts) error {
+func (p *Packager) runComponentScripts(scripts []types.ZarfScript, componentScript types.ZarfComponentScripts) error {
 	for _, script := range scripts {
 		if err := p.loopScriptUntilSuccess(script, componentScript); err != nil {
 			return err
@@ -29,7 +30,8 @@ func (p *Packager) runComponentScripts(scripts []string, componentScript types.Z
 	return nil
 }
 
-func (p *Packager) loopScriptUntilSuccess(script string, scripts types.ZarfComponentScripts) error {
+func (p *Packager) loopScriptUntilSuccess(zarfScript types.ZarfScript, scripts types.ZarfComponentScripts) error {
+	script := zarfScript.Cmd
 	spinner := message.NewProgressSpinner("Waiting for command \"%s\"", script)
 	defer spinner.Success()
 
@@ -86,6 +88,13 @@ func (p *Packager) loopScriptUntilSuccess(script string, scripts types.ZarfCompo
 				return fmt.Errorf("script \"%s\" failed: %w", script, err)
 			}
 
+			// Capture the output of the script for the rest of the deployment
+			if zarfScript.SetVariable != "" {
+				if err := p.setScriptVariable(zarfScript.SetVariable, output); err != nil {
+					return fmt.Errorf("script \"%s\" failed to set %s: %w", script, zarfScript.SetVariable, err)
+				}
+			}
+
 			// Dump the script output in debug if output not already streamed
 			if !scripts.ShowOutput {
 				message.Debug(output, errOut)
@@ -97,6 +106,26 @@ func (p *Packager) loopScriptUntilSuccess(script string, scripts types.ZarfCompo
 	}
 }
 
+// setScriptVariable sets a variable to the trimmed output of a script, validating it if the package declares the variable
+func (p *Packager) setScriptVariable(name, output string) error {
+	value := strings.TrimSpace(output)
+
+	for _, variable := range p.cfg.Pkg.Variables {
+		if variable.Name != name {
+			continue
+		}
+		if variable.IsSensitive() {
+			message.AddSensitiveValues(value)
+		}
+		if err := validate.VariableValue(variable, value); err != nil {
+			return err
+		}
+	}
+
+	p.cfg.SetVariableMap[name] = value
+	return nil
+}
+
 // Perform some basic string mutations to make scripts more useful
 func (p *Packager) scriptMutation(script string) (string, error) {
 
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying zarf packages
+package packager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/internal/packager/helm"
+	"github.com/defenseunicorns/zarf/src/types"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// setValuesOverrides reads the --values files and the component.chart.path keys of --set into the helm values of each chart.
+// Keys of --set without a dot are left as package variables.
+func (p *Packager) setValuesOverrides() error {
+	p.cfg.ValuesOverridesMap = make(map[string]map[string]any)
+
+	for _, entry := range p.cfg.DeployOpts.ValuesFiles {
+		chartKey, file, found := strings.Cut(entry, "=")
+		if !found || file == "" {
+			return fmt.Errorf("invalid values file %q, must be in the form component/chart=file", entry)
+		}
+		if err := p.validateChartKey(chartKey); err != nil {
+			return err
+		}
+
+		values, err := chartutil.ReadValuesFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read the values file %s: %w", file, err)
+		}
+		p.cfg.ValuesOverridesMap[chartKey] = helm.MergeValues(p.cfg.ValuesOverridesMap[chartKey], values)
+	}
+
+	// Apply --set values in a stable order after the values files so they take precedence
+	var keys []string
+	for key := range p.cfg.DeployOpts.SetVariables {
+		if strings.Contains(key, ".") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := p.cfg.DeployOpts.SetVariables[key]
+		delete(p.cfg.DeployOpts.SetVariables, key)
+
+		componentName, chartPath, _ := strings.Cut(key, ".")
+		chartName, valuePath, found := strings.Cut(chartPath, ".")
+		if !found || valuePath == "" {
+			return fmt.Errorf("invalid value %q, must be in the form component.chart.path=value", key)
+		}
+
+		chartKey := componentName + "/" + chartName
+		if err := p.validateChartKey(chartKey); err != nil {
+			return err
+		}
+
+		values := p.cfg.ValuesOverridesMap[chartKey]
+		if values == nil {
+			values = make(map[string]any)
+		}
+		if err := strvals.ParseInto(fmt.Sprintf("%s=%s", valuePath, value), values); err != nil {
+			return fmt.Errorf("unable to parse the value %s: %w", key, err)
+		}
+		p.cfg.ValuesOverridesMap[chartKey] = values
+	}
+
+	return nil
+}
+
+// validateChartKey returns an error if a component/chart key does not match a chart in the package
+func (p *Packager) validateChartKey(chartKey string) error {
+	componentName, chartName, _ := strings.Cut(chartKey, "/")
+	for _, component := range p.cfg.Pkg.Components {
+		if component.Name != componentName {
+			continue
+		}
+		for _, chart := range component.Charts {
+			if chart.Name == chartName {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("the package has no chart %s in component %s", chartName, componentName)
+}
+
+// getValuesOverrides returns the helm values set for each chart of a component, on top of the values recorded by the previous deployment
+func (p *Packager) getValuesOverrides(component types.ZarfComponent) (map[string]map[string]any, error) {
+	var previous types.DeployedComponent
+	if !p.cfg.DeployOpts.ResetValues {
+		deployedPackage, err := p.cluster.GetDeployedPackage(p.cfg.Pkg.Metadata.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to read the previous deployment of %s: %w", p.cfg.Pkg.Metadata.Name, err)
+		}
+		for _, deployedComponent := range deployedPackage.DeployedComponents {
+			if deployedComponent.Name == component.Name {
+				previous = deployedComponent
+			}
+		}
+	}
+
+	overrides := make(map[string]map[string]any)
+	for _, chart := range component.Charts {
+		values := helm.MergeValues(previous.ValuesOverrides[chart.Name], p.cfg.ValuesOverridesMap[component.Name+"/"+chart.Name])
+		if len(values) > 0 {
+			overrides[chart.Name] = values
+		}
+	}
+
+	return overrides, nil
+}
@@ -5,12 +5,26 @@
 package packager
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/defenseunicorns/zarf/src/config"
+	"github.com/defenseunicorns/zarf/src/internal/cluster"
+	"github.com/defenseunicorns/zarf/src/internal/packager/validate"
+	"github.com/defenseunicorns/zarf/src/pkg/message"
 	"github.com/defenseunicorns/zarf/src/pkg/utils"
 	"github.com/defenseunicorns/zarf/src/types"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // fillActiveTemplate handles setting the active variables and reloading the base template.
@@ -51,7 +65,11 @@ func (p *Packager) fillActiveTemplate() error {
 	// Add special variable for the current package architecture
 	templateMap["###ZARF_PKG_ARCH###"] = p.arch
 
-	return utils.ReloadYamlTemplate(&p.cfg.Pkg, templateMap)
+	if err := utils.ReloadYamlTemplate(&p.cfg.Pkg, templateMap); err != nil {
+		return fmt.Errorf("unable to template the package variables into %s: %w", config.ZarfYAML, err)
+	}
+
+	return nil
 }
 
 // setActiveVariables handles setting the active variables used to template component files.
@@ -62,33 +80,164 @@ func (p *Packager) setActiveVariables() error {
 		p.cfg.SetVariableMap[strings.ToUpper(key)] = value
 	}
 
+	// Collect every invalid variable so they can all be fixed before anything is deployed
+	var invalid []string
+
 	for _, variable := range p.cfg.Pkg.Variables {
 		_, present := p.cfg.SetVariableMap[variable.Name]
 
-		// Variable is present, no need to continue checking
-		if present {
-			continue
+		// Read the value from its source if it was not --set
+		if !present {
+			value, found, err := p.getSourcedValue(variable)
+			if err != nil {
+				invalid = append(invalid, err.Error())
+				continue
+			}
+			if found {
+				p.cfg.SetVariableMap[variable.Name] = value
+				present = true
+			}
 		}
 
-		// First set default (may be overridden by prompt)
-		p.cfg.SetVariableMap[variable.Name] = variable.Default
+		if !present {
+			// First set default (may be overridden by prompt)
+			p.cfg.SetVariableMap[variable.Name] = variable.Default
 
-		// Variable is set to prompt the user
-		if variable.Prompt && !config.CommonOptions.Confirm {
-			// Prompt the user for the variable
-			val, err := p.promptVariable(variable)
+			// Variable is set to prompt the user, required variables without a default are always prompted for
+			if (variable.Prompt || (variable.Required && variable.Default == "")) && !config.CommonOptions.Confirm {
+				// Prompt the user for the variable
+				val, err := p.promptVariable(variable)
 
-			if err != nil {
-				return err
+				if err != nil {
+					return err
+				}
+
+				p.cfg.SetVariableMap[variable.Name] = val
 			}
+		}
+
+		// Mask sensitive values before they can be printed (file variables are only sensitive once read)
+		if variable.IsSensitive() && variable.Type != config.ZarfVariableTypeFile {
+			message.AddSensitiveValues(p.cfg.SetVariableMap[variable.Name])
+		}
+
+		value, err := resolveVariableValue(variable, p.cfg.SetVariableMap[variable.Name])
+		if err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		p.cfg.SetVariableMap[variable.Name] = value
 
-			p.cfg.SetVariableMap[variable.Name] = val
+		if variable.IsSensitive() {
+			message.AddSensitiveValues(value)
 		}
 	}
 
+	if len(invalid) > 0 {
+		if config.CommonOptions.Confirm {
+			invalid = append(invalid, "variables are not prompted for with --confirm, provide them with --set instead")
+		}
+		return fmt.Errorf("invalid package variables:\n - %s", strings.Join(invalid, "\n - "))
+	}
+
 	return nil
 }
 
+// getSourcedValue reads the value of a variable from the environment, a file or the cluster, returning false if it was not found.
+func (p *Packager) getSourcedValue(variable types.ZarfPackageVariable) (string, bool, error) {
+	source := variable.From
+
+	switch {
+	case source.Env != "":
+		value, found := os.LookupEnv(source.Env)
+		return trimSourcedValue(variable, value), found, nil
+
+	case source.File != "":
+		content, err := os.ReadFile(source.File)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		} else if err != nil {
+			return "", false, fmt.Errorf("unable to read the file for variable '%s': %w", variable.Name, err)
+		}
+		return trimSourcedValue(variable, string(content)), true, nil
+
+	case source.Secret.Name != "" || source.ConfigMap.Name != "":
+		if p.cluster == nil {
+			var err error
+			if p.cluster, err = cluster.NewClusterWithWait(30 * time.Second); err != nil {
+				return "", false, fmt.Errorf("unable to connect to the Kubernetes cluster to read variable '%s': %w", variable.Name, err)
+			}
+		}
+
+		if source.Secret.Name != "" {
+			secret, err := p.cluster.Kube.GetSecret(sourceNamespace(source.Secret), source.Secret.Name)
+			if k8serrors.IsNotFound(err) {
+				return "", false, nil
+			} else if err != nil {
+				return "", false, fmt.Errorf("unable to read the secret for variable '%s': %w", variable.Name, err)
+			}
+			data, found := secret.Data[source.Secret.Key]
+			value := trimSourcedValue(variable, string(data))
+			// Secrets are always sensitive
+			message.AddSensitiveValues(value)
+			return value, found, nil
+		}
+
+		configMap, err := p.cluster.Kube.GetConfigmap(sourceNamespace(source.ConfigMap), source.ConfigMap.Name)
+		if k8serrors.IsNotFound(err) {
+			return "", false, nil
+		} else if err != nil {
+			return "", false, fmt.Errorf("unable to read the configmap for variable '%s': %w", variable.Name, err)
+		}
+		if value, found := configMap.Data[source.ConfigMap.Key]; found {
+			return trimSourcedValue(variable, value), true, nil
+		}
+		value, found := configMap.BinaryData[source.ConfigMap.Key]
+		return trimSourcedValue(variable, string(value)), found, nil
+	}
+
+	return "", false, nil
+}
+
+// trimSourcedValue drops the trailing newline files and keys usually end with, except for values that can span multiple lines
+func trimSourcedValue(variable types.ZarfPackageVariable, value string) string {
+	if variable.Type == config.ZarfVariableTypeMultiline || variable.Type == config.ZarfVariableTypeFile {
+		return value
+	}
+	return strings.TrimRight(value, "\r\n")
+}
+
+// sourceNamespace returns the namespace of a secret or configmap a variable is read from
+func sourceNamespace(key types.ZarfVariableSourceKey) string {
+	if key.Namespace == "" {
+		return corev1.NamespaceDefault
+	}
+	return key.Namespace
+}
+
+// resolveVariableValue validates the value of a variable and converts it to what is templated, file variables are replaced by the file content.
+func resolveVariableValue(variable types.ZarfPackageVariable, value string) (string, error) {
+	switch variable.Type {
+	case config.ZarfVariableTypeFile:
+		if value == "" {
+			return value, validate.VariableValue(variable, value)
+		}
+		content, err := os.ReadFile(value)
+		if err != nil {
+			return "", fmt.Errorf("unable to read the file for variable '%s': %w", variable.Name, err)
+		}
+		value = string(content)
+
+	case config.ZarfVariableTypeBool:
+		// Normalize values like "T" or "1" so they template as valid YAML booleans
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(parsed), validate.VariableValue(variable, strconv.FormatBool(parsed))
+		}
+	}
+
+	return value, validate.VariableValue(variable, value)
+}
+
 // injectImportedVariable determines if an imported package variable exists in the active config and adds it if not.
 func (p *Packager) injectImportedVariable(importedVariable types.ZarfPackageVariable) {
 	presentInActive := false
@@ -116,3 +265,98 @@ func (p *Packager) injectImportedConstant(importedConstant types.ZarfPackageCons
 		p.cfg.Pkg.Constants = append(p.cfg.Pkg.Constants, importedConstant)
 	}
 }
+
+// validatePackageTemplates checks that the variables and constants used by the packaged manifests, values files and charts are declared
+// and warns about declared ones that are never used, so typos don't ship as literal ###ZARF_VAR_*### strings.
+func (p *Packager) validatePackageTemplates() error {
+	declared := validate.DeclaredTemplates(p.cfg.Pkg)
+	used := make(map[string]bool)
+	var undeclared []string
+
+	checkText := func(name string, text []byte) {
+		for _, reference := range validate.FindTemplateReferences(text) {
+			used[reference.Key] = true
+			// Go template references are checked when they are rendered
+			if !reference.GoTemplate && !declared[reference.Key] {
+				undeclared = append(undeclared, fmt.Sprintf("%s (%s:%d)", reference.Key, name, reference.Line))
+			}
+		}
+	}
+
+	// Charts are checked with their packaged subcharts
+	var checkChart func(name string, helmChart *chart.Chart) error
+	checkChart = func(name string, helmChart *chart.Chart) error {
+		for _, file := range helmChart.Raw {
+			if strings.HasPrefix(file.Name, "charts/") && strings.HasSuffix(file.Name, ".tgz") {
+				subchart, err := loader.LoadArchive(bytes.NewReader(file.Data))
+				if err != nil {
+					return fmt.Errorf("unable to load the subchart %s of %s: %w", file.Name, name, err)
+				}
+				if err := checkChart(name+":"+file.Name, subchart); err != nil {
+					return err
+				}
+				continue
+			}
+			checkText(name+":"+file.Name, file.Data)
+		}
+		return nil
+	}
+
+	for _, component := range p.cfg.Pkg.Components {
+		componentPath, err := p.createComponentPaths(component)
+		if err != nil {
+			return fmt.Errorf("unable to access the component %s: %w", component.Name, err)
+		}
+
+		for _, dir := range []string{componentPath.Manifests, componentPath.Values} {
+			err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+				// Components without manifests or values don't have these directories
+				if errors.Is(err, fs.ErrNotExist) || (err == nil && entry.IsDir()) {
+					return nil
+				} else if err != nil {
+					return err
+				}
+
+				text, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				name, _ := filepath.Rel(p.tmp.Components, path)
+				checkText(name, text)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("unable to check the templates of the component %s: %w", component.Name, err)
+			}
+		}
+
+		chartPaths, _ := filepath.Glob(filepath.Join(componentPath.Charts, "*.tgz"))
+		for _, chartPath := range chartPaths {
+			helmChart, err := loader.Load(chartPath)
+			if err != nil {
+				return fmt.Errorf("unable to load the chart %s: %w", chartPath, err)
+			}
+			name, _ := filepath.Rel(p.tmp.Components, chartPath)
+			if err := checkChart(name, helmChart); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, variable := range p.cfg.Pkg.Variables {
+		if !used[fmt.Sprintf("###ZARF_VAR_%s###", variable.Name)] {
+			message.Warnf("The variable %s is declared but not used by the manifests, values files or charts of the package", variable.Name)
+		}
+	}
+	for _, constant := range p.cfg.Pkg.Constants {
+		if !used[fmt.Sprintf("###ZARF_CONST_%s###", constant.Name)] {
+			message.Warnf("The constant %s is declared but not used by the manifests, values files or charts of the package", constant.Name)
+		}
+	}
+
+	if len(undeclared) > 0 {
+		return fmt.Errorf("the package uses variables or constants that are not declared in its %s: %s", config.ZarfYAML, strings.Join(utils.Unique(undeclared), ", "))
+	}
+
+	return nil
+}
@@ -68,5 +68,9 @@ func (p *Packager) writeYaml() error {
 		p.cfg.Pkg.Build.Terminal = hostname
 	}
 
-	return utils.WriteYaml(p.tmp.ZarfYaml, p.cfg.Pkg, 0400)
+	// Paths into remote imports are recorded as they are in the imported package
+	pkg := p.cfg.Pkg
+	pkg.Components = p.relativeImportPaths(pkg.Components)
+
+	return utils.WriteYaml(p.tmp.ZarfYaml, pkg, 0400)
 }
@@ -47,6 +47,15 @@ func ExecCommandWithContextAndDir(ctx context.Context, dir string, showLogs bool
 	cmd.Dir = dir
 
 	var stdoutBuf, stderrBuf bytes.Buffer
+
+	// Only capture the output if it isn't shown
+	if !showLogs {
+		cmd.Stdout = &stdoutBuf
+		cmd.Stderr = &stderrBuf
+		err := cmd.Run()
+		return stdoutBuf.String(), stderrBuf.String(), err
+	}
+
 	stdoutIn, _ := cmd.StdoutPipe()
 	stderrIn, _ := cmd.StderrPipe()
 
@@ -58,27 +67,24 @@ func ExecCommandWithContextAndDir(ctx context.Context, dir string, showLogs bool
 		return "", "", err
 	}
 
-	if showLogs {
-		var wg sync.WaitGroup
-		wg.Add(1)
+	var wg sync.WaitGroup
+	wg.Add(1)
 
-		go func() {
-			_, errStdout = io.Copy(stdout, stdoutIn)
-			wg.Done()
-		}()
+	go func() {
+		_, errStdout = io.Copy(stdout, stdoutIn)
+		wg.Done()
+	}()
 
-		_, errStderr = io.Copy(stderr, stderrIn)
-		wg.Wait()
-	}
+	_, errStderr = io.Copy(stderr, stderrIn)
+	wg.Wait()
 
+	// Return the output of failed commands as well so the reason can be reported
 	if err := cmd.Wait(); err != nil {
-		return "", "", err
+		return stdoutBuf.String(), stderrBuf.String(), err
 	}
 
-	if showLogs {
-		if errStdout != nil || errStderr != nil {
-			return "", "", errors.New("unable to capture stdOut or stdErr")
-		}
+	if errStdout != nil || errStderr != nil {
+		return "", "", errors.New("unable to capture stdOut or stdErr")
 	}
 
 	return stdoutBuf.String(), stderrBuf.String(), nil
@@ -5,7 +5,6 @@
 package utils
 
 import (
-	"bytes"
 	"fmt"
 	"io/fs"
 	"os"
@@ -84,22 +83,6 @@ func WriteFile(path string, data []byte) error {
 	return nil
 }
 
-// ReplaceTextTemplate loads a file from a given path, replaces text in it and writes it back in place
-func ReplaceTextTemplate(path string, mappings map[string]string) {
-	text, err := os.ReadFile(path)
-	if err != nil {
-		message.Fatalf(err, "Unable to load %s", path)
-	}
-
-	for template, value := range mappings {
-		text = bytes.ReplaceAll(text, []byte(template), []byte(value))
-	}
-
-	if err = os.WriteFile(path, text, 0600); err != nil {
-		message.Fatalf(err, "Unable to update %s", path)
-	}
-}
-
 // RecursiveFileList walks a path with an optional regex pattern and returns a slice of file paths
 func RecursiveFileList(dir string, pattern *regexp.Regexp) (files []string, err error) {
 	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
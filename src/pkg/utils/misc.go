@@ -21,6 +21,16 @@ func Unique[T comparable](s []T) []T {
 	return result
 }
 
+// SliceContains returns true if the slice has an element equal to the given value
+func SliceContains[T comparable](s []T, value T) bool {
+	for _, element := range s {
+		if element == value {
+			return true
+		}
+	}
+	return false
+}
+
 // Retry will retry a function until it succeeds or the timeout is reached, timeout == retries * delay
 func Retry(fn func() error, retries int, delay time.Duration) (err error) {
 	for r := 0; r < retries; r++ {
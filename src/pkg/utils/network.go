@@ -21,6 +21,9 @@ import (
 
 const SGETProtocol = "sget://"
 
+// OCIURLPrefix marks a url as an OCI reference, e.g. oci://ghcr.io/org/repo:tag
+const OCIURLPrefix = "oci://"
+
 func IsUrl(source string) bool {
 	parsedUrl, err := url.Parse(source)
 	return err == nil && parsedUrl.Scheme != "" && parsedUrl.Host != ""
@@ -59,26 +62,26 @@ func Fetch(url string) io.ReadCloser {
 	return resp.Body
 }
 
-func DownloadToFile(url string, target string, cosignKeyPath string) {
+// DownloadToFile downloads a url (or an sget:// reference verified with cosignKeyPath) to the target file
+func DownloadToFile(url string, target string, cosignKeyPath string) error {
 
 	// Always ensure the target directory exists
 	if err := CreateFilePath(target); err != nil {
-		message.Fatalf(err, "Unable to create file path: %s", target)
+		return fmt.Errorf("unable to create file path %s: %w", target, err)
 	}
 
 	// Create the file
 	destinationFile, err := os.Create(target)
 	if err != nil {
-		message.Fatal(err, "Unable to create the destination file")
+		return fmt.Errorf("unable to create the destination file: %w", err)
 	}
 	defer destinationFile.Close()
 
 	// If the url start with the sget protocol use that, otherwise do a typical GET call
 	if strings.HasPrefix(url, SGETProtocol) {
-		sgetFile(url, destinationFile, cosignKeyPath)
-	} else {
-		httpGetFile(url, destinationFile)
+		return sgetFile(url, destinationFile, cosignKeyPath)
 	}
+	return httpGetFile(url, destinationFile)
 }
 
 // GetAvailablePort retrieves an available port on the host machine. This delegates the port selection to the golang net
@@ -105,17 +108,17 @@ func GetAvailablePort() (int, error) {
 	return port, err
 }
 
-func httpGetFile(url string, destinationFile *os.File) {
+func httpGetFile(url string, destinationFile *os.File) error {
 	// Get the data
 	resp, err := http.Get(url)
 	if err != nil {
-		message.Fatal(err, "Unable to download the file")
+		return fmt.Errorf("unable to download the file: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check server response
 	if resp.StatusCode != http.StatusOK {
-		message.Fatalf(nil, "Bad HTTP status: %s", resp.Status)
+		return fmt.Errorf("bad HTTP status: %s", resp.Status)
 	}
 
 	// Writer the body to file
@@ -124,17 +127,19 @@ func httpGetFile(url string, destinationFile *os.File) {
 	progressBar := message.NewProgressBar(resp.ContentLength, title)
 
 	if _, err = io.Copy(destinationFile, io.TeeReader(resp.Body, progressBar)); err != nil {
-		progressBar.Fatalf(err, "Unable to save the file %s", destinationFile.Name())
+		progressBar.Stop()
+		return fmt.Errorf("unable to save the file %s: %w", destinationFile.Name(), err)
 	}
 
 	progressBar.Success(text)
+	return nil
 }
 
-func sgetFile(url string, destinationFile *os.File, cosignKeyPath string) {
+func sgetFile(url string, destinationFile *os.File, cosignKeyPath string) error {
 	// Remove the custom protocol header from the url
 	_, url, _ = strings.Cut(url, SGETProtocol)
-	err := Sget(context.TODO(), url, cosignKeyPath, destinationFile)
-	if err != nil {
-		message.Fatalf(err, "Unable to download file with sget: %s\n", url)
+	if err := Sget(context.TODO(), url, cosignKeyPath, destinationFile); err != nil {
+		return fmt.Errorf("unable to download file with sget %s: %w", url, err)
 	}
+	return nil
 }
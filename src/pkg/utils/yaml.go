@@ -13,6 +13,7 @@ import (
 	"io/fs"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/defenseunicorns/zarf/src/pkg/message"
@@ -110,18 +111,24 @@ func ReloadYamlTemplate(config any, mappings map[string]string) error {
 		return err
 	}
 
-	for template, value := range mappings {
-		// Prevent user input from escaping the trailing " during yaml marshaling
-		lastIdx := len(value) - 1
-		if lastIdx > -1 && string(value[lastIdx]) == "\\" {
-			value = fmt.Sprintf("%s\\", value)
+	// Replace the values in the parsed YAML so they can't change its structure
+	templated, err := TemplateYaml(text, mappings)
+	if err != nil {
+		return err
+	}
+
+	if err := goyaml.Unmarshal(templated, config); err != nil {
+		var keys []string
+		for key := range mappings {
+			if bytes.Contains(text, []byte(key)) {
+				keys = append(keys, key)
+			}
 		}
-		// Properly escape " in the yaml text output
-		value = strings.ReplaceAll(value, "\"", "\\\"")
-		text = []byte(strings.ReplaceAll(string(text), template, value))
+		sort.Strings(keys)
+		return fmt.Errorf("the values of %s are not valid where they are used: %w", strings.Join(keys, ", "), err)
 	}
 
-	return goyaml.Unmarshal(text, config)
+	return nil
 }
 
 // FindYamlTemplates finds strings with a given prefix in a config.
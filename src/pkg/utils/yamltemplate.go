@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package utils provides generic helper functions
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/defenseunicorns/zarf/src/pkg/message"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotYaml is returned when templating text that can't be parsed as YAML.
+var ErrNotYaml = errors.New("the text is not valid YAML")
+
+// yamlTemplate tracks the placeholders that stand in for template keys while the YAML is parsed.
+// Unquoted keys like ###ZARF_VAR_NAME### would otherwise be read as comments.
+type yamlTemplate struct {
+	keys         []string
+	placeholders map[string]string
+	mappings     map[string]string
+	used         map[string]bool
+}
+
+// TemplateYaml replaces the template keys in a YAML document (or stream) with their values in the parsed YAML nodes,
+// so each value is quoted and escaped for where it is used (including multi-line values).
+// Unquoted keys take the type of their value (e.g. a number), quoted keys are always strings.
+// It returns ErrNotYaml if the text can't be parsed, and an error naming the templates used if the result can't be parsed.
+func TemplateYaml(text []byte, mappings map[string]string) ([]byte, error) {
+	t := yamlTemplate{
+		placeholders: make(map[string]string),
+		mappings:     mappings,
+		used:         make(map[string]bool),
+	}
+
+	for key := range mappings {
+		if bytes.Contains(text, []byte(key)) {
+			t.keys = append(t.keys, key)
+		}
+	}
+	if len(t.keys) == 0 {
+		return text, nil
+	}
+
+	// Replace longer keys first so they aren't broken up by keys they contain
+	sort.Slice(t.keys, func(i, j int) bool {
+		if len(t.keys[i]) != len(t.keys[j]) {
+			return len(t.keys[i]) > len(t.keys[j])
+		}
+		return t.keys[i] < t.keys[j]
+	})
+
+	placeheld := string(text)
+	for idx, key := range t.keys {
+		placeholder := fmt.Sprintf("zarf-template-%d-placeholder", idx)
+		t.placeholders[placeholder] = key
+		placeheld = strings.ReplaceAll(placeheld, key, placeholder)
+	}
+
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(placeheld))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrNotYaml, err.Error())
+		}
+		documents = append(documents, &document)
+	}
+
+	var templated bytes.Buffer
+	encoder := yaml.NewEncoder(&templated)
+	encoder.SetIndent(2)
+	for _, document := range documents {
+		t.replaceNode(document)
+		if err := encoder.Encode(document); err != nil {
+			return nil, fmt.Errorf("unable to template %s: %w", t.usedKeys(), err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("unable to template %s: %w", t.usedKeys(), err)
+	}
+
+	// Make sure the templated YAML can still be read
+	decoder = yaml.NewDecoder(bytes.NewReader(templated.Bytes()))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("templating %s produced invalid YAML: %w", t.usedKeys(), err)
+		}
+	}
+
+	return templated.Bytes(), nil
+}
+
+// replaceNode replaces the placeholders in the values of a node and its children, comments get back the original keys
+func (t yamlTemplate) replaceNode(node *yaml.Node) {
+	node.HeadComment = t.restoreKeys(node.HeadComment)
+	node.LineComment = t.restoreKeys(node.LineComment)
+	node.FootComment = t.restoreKeys(node.FootComment)
+
+	if node.Kind == yaml.ScalarNode {
+		value := node.Value
+		for placeholder, key := range t.placeholders {
+			if strings.Contains(value, placeholder) {
+				value = strings.ReplaceAll(value, placeholder, t.mappings[key])
+				t.used[key] = true
+			}
+		}
+
+		if value != node.Value {
+			node.Value = value
+			// Plain scalars are resolved again so e.g. a number stays a number, quoted scalars stay strings
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		t.replaceNode(child)
+	}
+}
+
+// restoreKeys puts the original template keys back into text outside of values (i.e. comments)
+func (t yamlTemplate) restoreKeys(text string) string {
+	for placeholder, key := range t.placeholders {
+		text = strings.ReplaceAll(text, placeholder, key)
+	}
+	return text
+}
+
+// usedKeys lists the template keys that were replaced for error messages
+func (t yamlTemplate) usedKeys() string {
+	var keys []string
+	for _, key := range t.keys {
+		if t.used[key] {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, ", ")
+}
+
+// ReplaceYamlTemplate loads a YAML file from a given path, replaces the template keys in its values and writes it back in place.
+// Files that can't be parsed as YAML have the keys replaced as plain text instead.
+func ReplaceYamlTemplate(path string, mappings map[string]string) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", path, err)
+	}
+
+	templated, err := TemplateYaml(text, mappings)
+	if errors.Is(err, ErrNotYaml) {
+		message.Debugf("Templating %s as text: %s", path, err.Error())
+		templated = text
+		for template, value := range mappings {
+			templated = bytes.ReplaceAll(templated, []byte(template), []byte(value))
+		}
+	} else if err != nil {
+		return fmt.Errorf("unable to template %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, templated, 0600); err != nil {
+		return fmt.Errorf("unable to update %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -49,6 +49,29 @@ func TestUseCLI(t *testing.T) {
 	assert.NoError(t, err, stdOut, stdErr)
 	assert.Contains(t, stdOut, expectedShasum, "The expected SHASUM should equal the actual SHASUM")
 
+	// Test `zarf prepare lint` for a valid package
+	stdOut, stdErr, err = e2e.execZarfCommand("prepare", "lint", "examples/game")
+	require.NoError(t, err, stdOut, stdErr)
+
+	// Test `zarf prepare lint` reports every problem with its location
+	lintPath := t.TempDir()
+	err = os.WriteFile(filepath.Join(lintPath, "zarf.yaml"), []byte("kind: ZarfPackageConfig\nmetadata:\n  name: lint\n  descriptoin: typo\ncomponents:\n  - name: lint\n    manifests:\n      - name: lint\n        files:\n          - missing.yaml\n"), 0600)
+	require.NoError(t, err)
+	_, stdErr, err = e2e.execZarfCommand("prepare", "lint", lintPath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "zarf.yaml:4: metadata.descriptoin")
+	require.Contains(t, stdErr, "zarf.yaml:10: components[0].manifests[0].files[0]")
+
+	// Test `zarf prepare lint` still checks the local files of a package with a remote import
+	err = os.WriteFile(filepath.Join(lintPath, "zarf.yaml"), []byte("kind: ZarfPackageConfig\nmetadata:\n  name: lint\ncomponents:\n  - name: remote\n    import:\n      url: oci://ghcr.io/example/lint:1.0.0\n  - name: lint\n    manifests:\n      - name: lint\n        files:\n          - configmap.yaml\n          - missing.yaml\n"), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(lintPath, "configmap.yaml"), []byte("kind: ConfigMap\ndata:\n  remote: ###ZARF_VAR_REMOTE###\n"), 0600)
+	require.NoError(t, err)
+	_, stdErr, err = e2e.execZarfCommand("prepare", "lint", lintPath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "zarf.yaml:13: components[1].manifests[0].files[1]")
+	require.Contains(t, stdErr, "variable 'REMOTE' is not declared in the package")
+
 	// Test `zarf version`
 	stdOut, _, err = e2e.execZarfCommand("version")
 	assert.NoError(t, err)
@@ -144,5 +167,15 @@ func TestUseCLI(t *testing.T) {
 	_, err = os.ReadFile(tlsKey)
 	require.NoError(t, err)
 
+	// Test that helm values in package.deploy.set of a config file are rejected as their keys are lowercased
+	configPath := filepath.Join(t.TempDir(), "zarf-config.toml")
+	err = os.WriteFile(configPath, []byte("[package.deploy.set]\n'podinfo.podinfo.replicaCount' = '2'\n"), 0600)
+	require.NoError(t, err)
+	os.Setenv("ZARF_CONFIG", configPath)
+	_, stdErr, err = e2e.execZarfCommand("package", "deploy", "examples/game", "--confirm")
+	os.Unsetenv("ZARF_CONFIG")
+	require.Error(t, err)
+	require.Contains(t, stdErr, "podinfo.podinfo.replicacount")
+
 	e2e.cleanFiles(shasumTestFilePath, cachePath, otherTmpPath, sbomPath, pkgName, tlsCA, tlsCert, tlsKey)
 }
@@ -6,6 +6,7 @@ package test
 
 import (
 	"fmt"
+	"os/exec"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -52,4 +53,20 @@ func TestComponentScripts(t *testing.T) {
 	// Deploy the simple script that should fail the timeout
 	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", path, "--confirm", "--components=timeout")
 	require.Error(t, err, stdOut, stdErr)
+
+	// The manifest of the script that sets a variable can only be deployed to a cluster
+	if !e2e.runClusterTests {
+		return
+	}
+
+	// Deploy the script that sets a variable and check that its output was templated into the manifest
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", path, "--confirm", "--components=set-variable")
+	require.NoError(t, err, stdOut, stdErr)
+
+	kubectlOut, err := exec.Command("kubectl", "-n", "zarf", "get", "configmap", "script-variable", "-o", "jsonpath={.data.greeting}").Output()
+	require.NoError(t, err)
+	require.Equal(t, "hello-from-a-script", string(kubectlOut))
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", path, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
 }
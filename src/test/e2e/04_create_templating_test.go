@@ -48,5 +48,15 @@ func TestCreateTemplating(t *testing.T) {
 	require.NoError(t, err)
 	require.Contains(t, string(builtConfig), "name: FOX\n  default: simple-configmap.yaml")
 
+	// Test that using an undeclared variable in a manifest results in an error
+	undeclaredPath := t.TempDir()
+	err = os.WriteFile(filepath.Join(undeclaredPath, "zarf.yaml"), []byte("kind: ZarfPackageConfig\nmetadata:\n  name: undeclared\ncomponents:\n  - name: undeclared\n    required: true\n    manifests:\n      - name: undeclared\n        files:\n          - configmap.yaml\n"), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(undeclaredPath, "configmap.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: undeclared\ndata:\n  typo: \"###ZARF_VAR_TYPO###\"\n"), 0600)
+	require.NoError(t, err)
+	_, stdErr, err = e2e.execZarfCommand("package", "create", undeclaredPath, "--confirm", "--output-directory", undeclaredPath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "###ZARF_VAR_TYPO###")
+
 	e2e.cleanFiles(cachePath, decompressPath, pkgName)
 }
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/mholt/archiver/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRemoteImport(t *testing.T) {
+	t.Log("E2E: Create with remote component imports")
+
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	cachePath := t.TempDir()
+	decompressPath := filepath.Join(os.TempDir(), ".remote-import-decompressed")
+	e2e.cleanFiles(decompressPath)
+
+	remotePath, err := filepath.Abs("examples/composable-packages/remote")
+	require.NoError(t, err)
+
+	// Serve the remote example as a tarball
+	tarballPath := filepath.Join(t.TempDir(), "remote.tar.gz")
+	require.NoError(t, archiver.Archive([]string{remotePath}, tarballPath))
+	shasum, err := utils.GetSha256Sum(tarballPath)
+	require.NoError(t, err)
+	tarballServer := httptest.NewServer(http.FileServer(http.Dir(filepath.Dir(tarballPath))))
+	defer tarballServer.Close()
+
+	// Commit the remote example to a git repository in the remote directory and tag it
+	repoPath := filepath.Join(t.TempDir(), "remote.git")
+	repo, err := git.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	require.NoError(t, utils.CreatePathAndCopy(remotePath, filepath.Join(repoPath, "remote")))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("remote")
+	require.NoError(t, err)
+	signature := &object.Signature{Name: "zarf", Email: "zarf@example.com", When: time.Now()}
+	commit, err := worktree.Commit("Add the remote example", &git.CommitOptions{Author: signature})
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	require.NoError(t, err)
+
+	// Push the remote example as the filesystem of an image to a local registry
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(remotePath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(remotePath, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)], err = os.ReadFile(path)
+		return err
+	})
+	require.NoError(t, err)
+	img, err := crane.Image(files)
+	require.NoError(t, err)
+	registryServer := httptest.NewServer(registry.New())
+	defer registryServer.Close()
+	ociRef := fmt.Sprintf("%s/remote:1.0.0", strings.TrimPrefix(registryServer.URL, "http://"))
+	require.NoError(t, crane.Push(img, ociRef))
+	digest, err := crane.Digest(ociRef)
+	require.NoError(t, err)
+
+	// Fill in the urls of the remote-imports example
+	importingPath := t.TempDir()
+	template, err := os.ReadFile("examples/composable-packages/remote-imports/zarf.yaml")
+	require.NoError(t, err)
+	writeImportingPackage := func(tarballShasum string) {
+		urls := map[string]string{
+			"TARBALL_URL":    tarballServer.URL + "/remote.tar.gz",
+			"TARBALL_SHASUM": tarballShasum,
+			"GIT_URL":        fmt.Sprintf("file://%s@v1.0.0", filepath.ToSlash(repoPath)),
+			"OCI_URL":        "oci://" + ociRef,
+		}
+		err := os.WriteFile(filepath.Join(importingPath, "zarf.yaml"), []byte(os.Expand(string(template), func(key string) string { return urls[key] })), 0600)
+		require.NoError(t, err)
+	}
+
+	// Test that a tarball with the wrong checksum is not imported
+	writeImportingPackage("0000000000000000000000000000000000000000000000000000000000000000")
+	_, stdErr, err := e2e.execZarfCommand("package", "create", importingPath, "--confirm", "--output-directory", importingPath, "--zarf-cache", cachePath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "shasum mismatch")
+
+	// Test that the components are imported and the resolved sources are recorded
+	writeImportingPackage(shasum)
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "create", importingPath, "--confirm", "--output-directory", importingPath, "--zarf-cache", cachePath, "--insecure")
+	require.NoError(t, err, stdOut, stdErr)
+
+	pkgName := filepath.Join(importingPath, fmt.Sprintf("zarf-package-remote-imports-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("t", "archiver", "decompress", pkgName, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	builtConfig, err := os.ReadFile(filepath.Join(decompressPath, "zarf.yaml"))
+	require.NoError(t, err)
+	var pkg types.ZarfPackage
+	require.NoError(t, yaml.Unmarshal(builtConfig, &pkg))
+
+	require.Equal(t, "sha256:"+shasum, pkg.Build.ComponentImports[tarballServer.URL+"/remote.tar.gz"].Digest)
+	require.Equal(t, commit.String(), pkg.Build.ComponentImports[fmt.Sprintf("file://%s@v1.0.0", filepath.ToSlash(repoPath))].Digest)
+	require.Equal(t, digest, pkg.Build.ComponentImports["oci://"+ociRef].Digest)
+
+	// Check that the paths are recorded as they are in the remote package and that its files were packaged
+	require.Len(t, pkg.Components, 3)
+	for _, component := range pkg.Components {
+		require.Equal(t, "hello.txt", component.Files[0].Source, component.Name)
+		require.Equal(t, "manifests/configmap.yaml", component.Manifests[0].Files[0], component.Name)
+		require.Equal(t, "chart", component.Charts[0].LocalPath, component.Name)
+
+		_, err = os.ReadFile(filepath.Join(decompressPath, "components", component.Name, "files", "0"))
+		require.NoError(t, err, component.Name)
+		require.FileExists(t, filepath.Join(decompressPath, "components", component.Name, "charts", "remote-hello-0.1.0.tgz"), component.Name)
+	}
+	require.NotContains(t, string(builtConfig), cachePath)
+
+	// Check that the git checkout isn't packaged
+	require.NoDirExists(t, filepath.Join(decompressPath, "imports"))
+
+	// Check that the tarball and the image are cached
+	stdOut, stdErr, err = e2e.execZarfCommand("tools", "cache", "list", "--zarf-cache", cachePath)
+	require.NoError(t, err, stdOut, stdErr)
+	require.Contains(t, stdErr, "sha256-"+shasum)
+	require.Contains(t, stdErr, strings.Replace(digest, ":", "-", 1))
+
+	e2e.cleanFiles(decompressPath)
+}
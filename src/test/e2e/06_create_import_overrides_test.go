@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/pkg/utils"
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateImportOverrides(t *testing.T) {
+	t.Log("E2E: Create with a whole package import and overrides")
+
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	decompressPath := filepath.Join(os.TempDir(), ".import-overrides-decompressed")
+	e2e.cleanFiles(decompressPath)
+
+	outputPath := t.TempDir()
+
+	// Test that an override has to match an imported component, in a copy of the examples it imports from
+	examplesPath := filepath.Join(t.TempDir(), "examples")
+	for _, example := range []string{"composable-packages", "helm-local-chart"} {
+		require.NoError(t, utils.CreatePathAndCopy(filepath.Join("examples", example), filepath.Join(examplesPath, example)))
+	}
+	invalidPath := filepath.Join(examplesPath, "composable-packages", "import-overrides")
+	importingConfig, err := os.ReadFile(filepath.Join(invalidPath, "zarf.yaml"))
+	require.NoError(t, err)
+	invalidConfig := strings.Replace(string(importingConfig), "component: app", "component: missing", 1)
+	require.NoError(t, os.WriteFile(filepath.Join(invalidPath, "zarf.yaml"), []byte(invalidConfig), 0600))
+	_, stdErr, err := e2e.execZarfCommand("package", "create", invalidPath, "--confirm", "--output-directory", outputPath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "the override for the component missing")
+
+	// Test that every component is imported and the overrides are applied
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "create", "examples/composable-packages/import-overrides", "--confirm", "--output-directory", outputPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	pkgName := filepath.Join(outputPath, fmt.Sprintf("zarf-package-import-overrides-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("t", "archiver", "decompress", pkgName, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	builtConfig, err := os.ReadFile(filepath.Join(decompressPath, "zarf.yaml"))
+	require.NoError(t, err)
+	var pkg types.ZarfPackage
+	require.NoError(t, yaml.Unmarshal(builtConfig, &pkg))
+
+	require.Len(t, pkg.Components, 3)
+	require.Equal(t, "vendor-app", pkg.Components[0].Name)
+	require.True(t, pkg.Components[0].Required)
+	require.Empty(t, pkg.Components[0].Images)
+	require.Len(t, pkg.Components[0].Charts, 1)
+	// Local chart paths are relative to the package being created rather than to the imported package
+	require.Equal(t, "../../helm-local-chart/chart", pkg.Components[0].Charts[0].LocalPath)
+	require.Equal(t, "overridden", pkg.Components[0].Charts[0].Namespace)
+	require.Len(t, pkg.Components[0].Charts[0].ValuesFiles, 1)
+
+	require.Equal(t, "vendor-extra-a", pkg.Components[1].Name)
+	require.Equal(t, "vendor-extras", pkg.Components[1].Group)
+	require.True(t, pkg.Components[1].Default)
+	require.Equal(t, "vendor-extra-b", pkg.Components[2].Name)
+	require.Equal(t, "vendor-extras", pkg.Components[2].Group)
+
+	e2e.cleanFiles(decompressPath)
+}
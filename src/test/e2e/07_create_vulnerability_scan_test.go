@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/zarf/src/types"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/require"
+
+	// Register the sqlite driver used to load the example vulnerability database
+	_ "modernc.org/sqlite"
+)
+
+// dpkgStatus lists the Debian packages of the scanned image
+const dpkgStatus = `Package: epoch-demo
+Status: install ok installed
+Architecture: amd64
+Version: 1:1.0-1
+Description: A package with an epoch
+
+Package: tilde-demo
+Status: install ok installed
+Architecture: amd64
+Version: 2.0~rc1-1
+Description: A package with a tilde pre-release
+
+Package: suffix-demo
+Status: install ok installed
+Architecture: amd64
+Version: 1.0b1-1
+Description: A package with letters after its version
+`
+
+func TestCreateVulnerabilityScan(t *testing.T) {
+	t.Log("E2E: Create with a vulnerability scan")
+
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	decompressPath := filepath.Join(os.TempDir(), ".vulnerability-scan-decompressed")
+	e2e.cleanFiles(decompressPath)
+
+	// Load the example vulnerability database
+	dbPath := filepath.Join(t.TempDir(), "vulnerability.db")
+	script, err := os.ReadFile("examples/vulnerability-scan/vulnerability.sql")
+	require.NoError(t, err)
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(string(script))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// Push an image with the Debian, npm and python packages the database has advisories for to a local registry
+	img, err := crane.Image(map[string][]byte{
+		"etc/os-release":      []byte("ID=debian\nVERSION_ID=\"11\"\n"),
+		"var/lib/dpkg/status": []byte(dpkgStatus),
+		"usr/lib/node_modules/prerelease-demo/package.json":                      []byte(`{"name": "prerelease-demo", "version": "1.2.0-rc.1"}`),
+		"usr/lib/node_modules/ranges-demo/package.json":                          []byte(`{"name": "ranges-demo", "version": "2.0.1"}`),
+		"usr/lib/python3/site-packages/Mixed_Case.Demo-1.0.0.dist-info/METADATA": []byte("Metadata-Version: 2.1\nName: Mixed_Case.Demo\nVersion: 1.0.0\n"),
+	})
+	require.NoError(t, err)
+	registryServer := httptest.NewServer(registry.New())
+	defer registryServer.Close()
+	imageRef := fmt.Sprintf("%s/scanned:1.0.0", strings.TrimPrefix(registryServer.URL, "http://"))
+	require.NoError(t, crane.Push(img, imageRef))
+
+	outputPath := t.TempDir()
+	createArgs := []string{"package", "create", "examples/vulnerability-scan", "--confirm", "--insecure", "--output-directory", outputPath,
+		"--set", "IMAGE=" + imageRef, "--vuln-db", dbPath}
+
+	// Test that the package create fails on a vulnerability at or above the threshold
+	_, stdErr, err := e2e.execZarfCommand(append(createArgs, "--vuln-fail-on", "critical")...)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "found 1 vulnerabilities at or above Critical severity")
+
+	// Test that only the affected versions are matched
+	stdOut, stdErr, err := e2e.execZarfCommand(createArgs...)
+	require.NoError(t, err, stdOut, stdErr)
+
+	pkgName := filepath.Join(outputPath, fmt.Sprintf("zarf-package-vulnerability-scan-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("t", "archiver", "decompress", pkgName, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	reportJSON, err := os.ReadFile(filepath.Join(decompressPath, "vulnerabilities.json"))
+	require.NoError(t, err)
+	var report types.VulnerabilityReport
+	require.NoError(t, json.Unmarshal(reportJSON, &report))
+
+	var matched []string
+	for _, match := range report.Matches {
+		matched = append(matched, fmt.Sprintf("%s %s", match.Package, match.Vulnerability))
+	}
+	require.ElementsMatch(t, []string{
+		// 1:1.0-1 is older than 1:1.5 but newer than 2.0 (which has no epoch)
+		"epoch-demo CVE-0000-0001",
+		// 2.0~rc1-1 is older than 2.0-1 but newer than 2.0~beta1
+		"tilde-demo CVE-0000-0003",
+		// 1.2.0-rc.1 is older than 1.2.0
+		"prerelease-demo GHSA-0000-0001",
+		// 2.0.1 is within the second range
+		"ranges-demo GHSA-0000-0003",
+		// Mixed_Case.Demo is recorded as mixed-case-demo
+		"Mixed_Case.Demo GHSA-0000-0005",
+	}, matched)
+
+	e2e.cleanFiles(decompressPath)
+}
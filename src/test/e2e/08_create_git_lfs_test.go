@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+// lfsContent is the content of the LFS object in the served repo
+var lfsContent = []byte("model weights that are too large for git\n")
+
+// lfsServer serves a git repo with an LFS pointer (through git http-backend) and the LFS batch API for its object
+type lfsServer struct {
+	*httptest.Server
+	repoURL string
+	oid     string
+	// corrupt serves the object with a different content of the same size
+	corrupt bool
+	// token is required as the password of basic auth (with the oauth2 username) when set
+	token string
+}
+
+func newLFSServer(t *testing.T) *lfsServer {
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(lfsContent)
+	server := &lfsServer{oid: hex.EncodeToString(hash[:])}
+
+	// Commit the pointer file to a repo and serve a bare clone of it
+	workPath := t.TempDir()
+	repo, err := git.PlainInit(workPath, false)
+	require.NoError(t, err)
+	pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", server.oid, len(lfsContent))
+	require.NoError(t, os.WriteFile(filepath.Join(workPath, "model.bin"), []byte(pointer), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(workPath, ".gitattributes"), []byte("*.bin filter=lfs diff=lfs merge=lfs -text\n"), 0600))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add(".")
+	require.NoError(t, err)
+	signature := &object.Signature{Name: "zarf", Email: "zarf@example.com", When: time.Now()}
+	_, err = worktree.Commit("Add the model", &git.CommitOptions{Author: signature})
+	require.NoError(t, err)
+
+	rootPath := t.TempDir()
+	_, err = git.PlainClone(filepath.Join(rootPath, "lfs-demo.git"), true, &git.CloneOptions{URL: workPath})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lfs-demo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.git-lfs+json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"objects": []map[string]any{{
+				"oid":  server.oid,
+				"size": len(lfsContent),
+				"actions": map[string]any{
+					// Like GitLab, pass the credentials of the batch request on to the transfer
+					"download": map[string]any{
+						"href":   server.URL + "/objects/" + server.oid,
+						"header": map[string]string{"Authorization": r.Header.Get("Authorization")},
+					},
+				},
+			}},
+		})
+	})
+	mux.HandleFunc("/objects/"+server.oid, func(w http.ResponseWriter, r *http.Request) {
+		if server.corrupt {
+			_, _ = w.Write([]byte(strings.ToUpper(string(lfsContent))))
+			return
+		}
+		_, _ = w.Write(lfsContent)
+	})
+	mux.Handle("/", &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + rootPath, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); server.token != "" && (username != "oauth2" || password != server.token) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="lfs-demo"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	server.repoURL = server.URL + "/lfs-demo.git"
+
+	return server
+}
+
+func TestCreateGitLFS(t *testing.T) {
+	t.Log("E2E: Create with the Git LFS objects of a repo")
+
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	decompressPath := filepath.Join(os.TempDir(), ".git-lfs-decompressed")
+	e2e.cleanFiles(decompressPath)
+
+	server := newLFSServer(t)
+	defer server.Close()
+
+	outputPath := t.TempDir()
+	createArgs := []string{"package", "create", "examples/git-lfs", "--confirm", "--output-directory", outputPath,
+		"--set", "REPO_URL=" + server.repoURL}
+
+	// Test that an object that doesn't match its pointer is not packaged
+	server.corrupt = true
+	_, stdErr, err := e2e.execZarfCommand(append(createArgs, "--zarf-cache", t.TempDir())...)
+	require.Error(t, err)
+	require.Contains(t, stdErr, server.oid+" failed verification")
+
+	server.corrupt = false
+	server.token = "lfs-demo-token"
+
+	// Test that the credentials of a netrc machine entry are found after a macro
+	netrcPath := filepath.Join(t.TempDir(), ".netrc")
+	netrc := fmt.Sprintf("macdef init\ncd /tmp\nput model.bin\n\nmachine %s login oauth2 password %s\n", server.Listener.Addr().(*net.TCPAddr).IP, server.token)
+	require.NoError(t, os.WriteFile(netrcPath, []byte(netrc), 0600))
+	os.Setenv("NETRC", netrcPath)
+	stdOut, stdErr, err := e2e.execZarfCommand(append(createArgs, "--zarf-cache", t.TempDir())...)
+	os.Unsetenv("NETRC")
+	require.NoError(t, err, stdOut, stdErr)
+
+	// Test that the object is downloaded into the packaged repo, with the access token of the host
+	tokenEnv := "ZARF_GIT_TOKEN_" + strings.NewReplacer(".", "_", ":", "_").Replace(server.Listener.Addr().(*net.TCPAddr).IP.String())
+	os.Setenv(tokenEnv, server.token)
+	defer os.Unsetenv(tokenEnv)
+	stdOut, stdErr, err = e2e.execZarfCommand(append(createArgs, "--zarf-cache", t.TempDir())...)
+	require.NoError(t, err, stdOut, stdErr)
+
+	pkgName := filepath.Join(outputPath, fmt.Sprintf("zarf-package-git-lfs-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("t", "archiver", "decompress", pkgName, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	var objectPaths []string
+	err = filepath.WalkDir(filepath.Join(decompressPath, "components", "lfs-repo", "repos"), func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.Name() == server.oid {
+			objectPaths = append(objectPaths, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, objectPaths, 1)
+	require.True(t, strings.HasSuffix(objectPaths[0], filepath.Join(".git", "lfs", "objects", server.oid[0:2], server.oid[2:4], server.oid)), objectPaths[0])
+	packaged, err := os.ReadFile(objectPaths[0])
+	require.NoError(t, err)
+	require.Equal(t, lfsContent, packaged)
+
+	// Check that the LFS content is recorded for package inspect
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "inspect", pkgName)
+	require.NoError(t, err, stdOut, stdErr)
+	require.Contains(t, stdOut+stdErr, "LFS Objects")
+	require.Contains(t, stdOut+stdErr, server.repoURL)
+
+	e2e.cleanFiles(decompressPath)
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	goConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+// scopedRepo is a repo served with git http-backend whose branches and tags point at a line of commits,
+// the fifth commit is only within the history of a branch (git http-backend doesn't serve commits by hash)
+type scopedRepo struct {
+	*httptest.Server
+	repoURL string
+	commits []plumbing.Hash
+}
+
+func newScopedRepo(t *testing.T) *scopedRepo {
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+
+	server := &scopedRepo{}
+
+	workPath := t.TempDir()
+	repo, err := git.PlainInit(workPath, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	for idx := 1; idx <= 6; idx++ {
+		require.NoError(t, os.WriteFile(filepath.Join(workPath, "version.txt"), []byte(fmt.Sprintf("%d\n", idx)), 0600))
+		_, err = worktree.Add("version.txt")
+		require.NoError(t, err)
+		signature := &object.Signature{Name: "zarf", Email: "zarf@example.com", When: time.Now()}
+		commit, err := worktree.Commit(fmt.Sprintf("Commit %d", idx), &git.CommitOptions{Author: signature})
+		require.NoError(t, err)
+		server.commits = append(server.commits, commit)
+	}
+
+	refs := map[plumbing.ReferenceName]plumbing.Hash{
+		plumbing.NewBranchReferenceName("main"):      server.commits[3],
+		plumbing.NewBranchReferenceName("release-1"): server.commits[1],
+		plumbing.NewBranchReferenceName("release-2"): server.commits[2],
+		plumbing.NewBranchReferenceName("feature-x"): server.commits[5],
+		plumbing.NewTagReferenceName("v1.0.0"):       server.commits[0],
+		plumbing.NewTagReferenceName("v1.1.0"):       server.commits[1],
+		plumbing.NewTagReferenceName("v2.0.0"):       server.commits[2],
+	}
+	for name, hash := range refs {
+		require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(name, hash)))
+	}
+
+	// Push the refs to a bare repo whose default branch is main
+	rootPath := t.TempDir()
+	barePath := filepath.Join(rootPath, "scoped-demo.git")
+	bare, err := git.PlainInit(barePath, true)
+	require.NoError(t, err)
+	require.NoError(t, bare.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))))
+	remote, err := repo.CreateRemote(&goConfig.RemoteConfig{Name: "bare", URLs: []string{barePath}})
+	require.NoError(t, err)
+	err = remote.Push(&git.PushOptions{
+		RemoteName: "bare",
+		RefSpecs:   []goConfig.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	require.NoError(t, err)
+
+	server.Server = httptest.NewServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + rootPath, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	server.repoURL = server.URL + "/scoped-demo.git"
+
+	return server
+}
+
+// packagedRefs returns the branches and tags that are pushed from the repo packaged in a component
+func packagedRefs(t *testing.T, componentPath string) map[string]plumbing.Hash {
+	var repoPaths []string
+	err := filepath.WalkDir(filepath.Join(componentPath, "repos"), func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && entry.IsDir() && entry.Name() == ".git" {
+			repoPaths = append(repoPaths, filepath.Dir(path))
+			return filepath.SkipDir
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, repoPaths, 1)
+
+	repo, err := git.PlainOpen(repoPaths[0])
+	require.NoError(t, err)
+	references, err := repo.References()
+	require.NoError(t, err)
+
+	refs := make(map[string]plumbing.Hash)
+	err = references.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().String()
+		switch {
+		case ref.Type() != plumbing.HashReference:
+		case ref.Name().IsBranch(), strings.HasPrefix(name, "refs/remotes/online-upstream/"):
+			refs["branch "+name[strings.LastIndex(name, "/")+1:]] = ref.Hash()
+		case ref.Name().IsTag():
+			refs["tag "+ref.Name().Short()] = ref.Hash()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	return refs
+}
+
+func TestCreateGitScopedRepos(t *testing.T) {
+	t.Log("E2E: Create with only some of the branches and tags of a repo")
+
+	e2e.setup(t)
+	defer e2e.teardown(t)
+
+	decompressPath := filepath.Join(os.TempDir(), ".git-scoped-repos-decompressed")
+	e2e.cleanFiles(decompressPath)
+
+	server := newScopedRepo(t)
+	defer server.Close()
+
+	// Test that a shallow repo is rejected as it couldn't be pushed
+	shallowPath := t.TempDir()
+	shallowConfig := fmt.Sprintf("kind: ZarfPackageConfig\nmetadata:\n  name: shallow\ncomponents:\n  - name: shallow\n    repos:\n      - url: %s\n        depth: 1\n", server.repoURL)
+	require.NoError(t, os.WriteFile(filepath.Join(shallowPath, "zarf.yaml"), []byte(shallowConfig), 0600))
+	_, stdErr, err := e2e.execZarfCommand("package", "create", shallowPath, "--confirm", "--output-directory", shallowPath)
+	require.Error(t, err)
+	require.Contains(t, stdErr, "can't set a depth")
+
+	// Test that only the matching refs and the pinned commit are packaged
+	outputPath := t.TempDir()
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "create", "examples/git-scoped-repos", "--confirm", "--output-directory", outputPath,
+		"--set", "REPO_URL="+server.repoURL, "--set", "COMMIT="+server.commits[4].String())
+	require.NoError(t, err, stdOut, stdErr)
+
+	pkgName := filepath.Join(outputPath, fmt.Sprintf("zarf-package-git-scoped-repos-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("t", "archiver", "decompress", pkgName, decompressPath)
+	require.NoError(t, err, stdOut, stdErr)
+
+	// Without the default branch the first included branch becomes the default
+	require.Equal(t, map[string]plumbing.Hash{
+		"branch release-1": server.commits[1],
+		"branch release-2": server.commits[2],
+		"tag v1.0.0":       server.commits[0],
+		"tag v1.1.0":       server.commits[1],
+	}, packagedRefs(t, filepath.Join(decompressPath, "components", "release-refs")))
+
+	// The commit is pushed as the default branch (main) even though it's only in the history of another branch
+	require.Equal(t, map[string]plumbing.Hash{
+		"branch main": server.commits[4],
+		"tag v2.0.0":  server.commits[2],
+	}, packagedRefs(t, filepath.Join(decompressPath, "components", "pinned-commit")))
+
+	e2e.cleanFiles(decompressPath)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -38,6 +39,7 @@ func TestGitAndFlux(t *testing.T) {
 	testGitServerConnect(t, tunnel.HttpEndpoint())
 	testGitServerReadOnly(t, tunnel.HttpEndpoint())
 	testGitServerTagAndHash(t, tunnel.HttpEndpoint())
+	testGitServerLFS(t, tunnel.HttpEndpoint())
 	waitFluxPodInfoDeployment(t)
 
 	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "flux-test", "--confirm")
@@ -107,6 +109,34 @@ func testGitServerTagAndHash(t *testing.T, gitURL string) {
 	assert.Equal(t, repoHash, commitMap[0]["sha"])
 }
 
+func testGitServerLFS(t *testing.T, gitURL string) {
+	server := newLFSServer(t)
+	defer server.Close()
+
+	// Package and deploy a repo with an LFS object
+	outputPath := t.TempDir()
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "create", "examples/git-lfs", "--confirm", "--output-directory", outputPath, "--set", "REPO_URL="+server.repoURL)
+	require.NoError(t, err, stdOut, stdErr)
+	path := filepath.Join(outputPath, fmt.Sprintf("zarf-package-git-lfs-%s.tar.zst", e2e.arch))
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "deploy", path, "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+
+	state, err := cluster.NewClusterOrDie().LoadZarfState()
+	require.NoError(t, err, "Failed to load Zarf state")
+	gitCfg := git.New(state.GitServer)
+	repoName, err := gitCfg.TransformURLtoRepoName(server.repoURL)
+	require.NoError(t, err)
+
+	// Make sure the object was uploaded to the LFS endpoint of the internal git server
+	getMediaRequest, _ := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/repos/%s/%s/media/model.bin", gitURL, config.ZarfGitPushUser, repoName), nil)
+	getMediaResponseBody, err := gitCfg.DoHttpThings(getMediaRequest, config.ZarfGitReadUser, state.GitServer.PullPassword)
+	require.NoError(t, err)
+	assert.Equal(t, lfsContent, getMediaResponseBody)
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "git-lfs", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+}
+
 func waitFluxPodInfoDeployment(t *testing.T) {
 	// Deploy the flux example and verify that it works
 	path := fmt.Sprintf("build/zarf-package-flux-test-%s.tar.zst", e2e.arch)
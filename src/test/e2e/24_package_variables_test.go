@@ -26,7 +26,7 @@ func TestPackageVariables(t *testing.T) {
 	require.Contains(t, stdErr, "", expectedOutString)
 
 	// Deploy the simple configmap
-	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm", "--set", "CAT=meow")
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm", "--set", "CAT=meow", "--set", "AUTOMOUNT=f")
 	require.NoError(t, err, stdOut, stdErr)
 
 	// Verify the configmap was properly templated
@@ -41,8 +41,22 @@ func TestPackageVariables(t *testing.T) {
 	assert.Contains(t, string(kubectlOut), "fox=simple-configmap.yaml")
 	// dingo should take the constant value
 	assert.Contains(t, string(kubectlOut), "dingo=howl")
-	// zebra should remain unset as it is not a component variable
-	assert.Contains(t, string(kubectlOut), "zebra=###ZARF_VAR_ZEBRA###")
+
+	// Verify that unquoted templates take the type of their value (the bool is normalized)
+	kubectlOut, _ = exec.Command("kubectl", "-n", "zarf", "get", "serviceaccount", "templating-example", "-o", "jsonpath={.automountServiceAccountToken}").Output()
+	assert.Equal(t, "false", string(kubectlOut))
+
+	// Verify that values are escaped for where they are used in the second document of the file
+	kubectlOut, _ = exec.Command("kubectl", "-n", "zarf", "get", "configmap", "templating-example", "-o", "jsonpath={.data.banner}").Output()
+	assert.Equal(t, `Welcome to "zarf": # not a comment`, string(kubectlOut))
+	kubectlOut, _ = exec.Command("kubectl", "-n", "zarf", "get", "configmap", "templating-example", "-o", "jsonpath={.data.quoted-dog}").Output()
+	assert.Equal(t, "woof", string(kubectlOut))
+	kubectlOut, _ = exec.Command("kubectl", "-n", "zarf", "get", "configmap", "templating-example", "-o", "jsonpath={.data.ca\\.crt}").Output()
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----\nexample\n-----END CERTIFICATE-----", string(kubectlOut))
+
+	// Verify that a file that is only valid YAML once templated has its templates replaced as text
+	kubectlOut, _ = exec.Command("kubectl", "-n", "zarf", "get", "configmap", "templating-text", "-o", "jsonpath={.data.injected}").Output()
+	assert.Equal(t, "as-text", string(kubectlOut))
 
 	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", path, "--confirm")
 	require.NoError(t, err, stdOut, stdErr)
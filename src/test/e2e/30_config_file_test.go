@@ -56,6 +56,13 @@ func configFileTests(t *testing.T, dir, path string) {
 	kubectlOut, _ := exec.Command("kubectl", "-n", "zarf", "get", "configmap", "simple-configmap", "-o", "jsonpath='{.data.templateme\\.properties}' ").Output()
 	require.Contains(t, string(kubectlOut), "scorpion=iridescent")
 	require.Contains(t, string(kubectlOut), "camel_spider=matte")
+
+	// Verify the profile is applied over the config file
+	_, stdErr, err = e2e.execZarfCommand("prepare", "show-config", "--profile", "night")
+	require.NoError(t, err)
+	require.Contains(t, string(stdErr), "glowing")
+	require.Contains(t, string(stdErr), "profile night")
+	require.Contains(t, string(stdErr), "matte")
 }
 
 func configFileDefaultTests(t *testing.T) {
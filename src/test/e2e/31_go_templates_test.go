@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package test provides e2e tests for zarf
+package test
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoTemplates(t *testing.T) {
+	t.Log("E2E: Go templated manifests")
+	e2e.setupWithCluster(t)
+	defer e2e.teardown(t)
+
+	path := fmt.Sprintf("build/zarf-package-go-templates-%s.tar.zst", e2e.arch)
+
+	stdOut, stdErr, err := e2e.execZarfCommand("package", "deploy", path, "--confirm", "--set", "REPLICAS=3", "--set", "TLS_ENABLED=true")
+	require.NoError(t, err, stdOut, stdErr)
+
+	getData := func(key string) string {
+		kubectlOut, _ := exec.Command("kubectl", "-n", "go-template-demo", "get", "configmap", "template-demo", "-o", fmt.Sprintf("jsonpath={.data.%s}", key)).Output()
+		return string(kubectlOut)
+	}
+
+	// The variables are converted to their types and the constants and Zarf values are available
+	assert.Equal(t, "3", getData("replicas"))
+	assert.Equal(t, "enabled", getData("tls"))
+	assert.Contains(t, getData("hosts"), "- demo.example.com")
+	assert.NotEmpty(t, getData("registry"))
+
+	// A value containing {{ is not rendered again by helm
+	assert.Equal(t, "Hello {{ name }}", getData("greeting"))
+
+	stdOut, stdErr, err = e2e.execZarfCommand("package", "remove", "go-templates", "--confirm")
+	require.NoError(t, err, stdOut, stdErr)
+}
@@ -5,6 +5,12 @@
 
 package types
 
+import (
+	"encoding/json"
+
+	"github.com/alecthomas/jsonschema"
+)
+
 // ZarfComponent is the primary functional grouping of assets to deploy by zarf.
 type ZarfComponent struct {
 	// Name is the unique identifier for this component
@@ -32,6 +38,9 @@ type ZarfComponent struct {
 	// Import refers to another zarf.yaml package component.
 	Import ZarfComponentImport `json:"import,omitempty" jsonschema:"description=Import a component from another Zarf package"`
 
+	// Overrides patch the imported component(s) before the fields of this component are merged in
+	Overrides []ZarfComponentOverride `json:"overrides,omitempty" jsonschema:"description=Patches applied in order to the imported component(s)"`
+
 	// Scripts are custom commands that run before or after package deployment
 	Scripts ZarfComponentScripts `json:"scripts,omitempty" jsonschema:"description=Custom commands to run before or after package deployment"`
 
@@ -48,12 +57,96 @@ type ZarfComponent struct {
 	Images []string `json:"images,omitempty" jsonschema:"description=List of OCI images to include in the package"`
 
 	// Repos are any git repos that need to be pushed into the git server
-	Repos []string `json:"repos,omitempty" jsonschema:"description=List of git repos to include in the package"`
+	Repos []ZarfRepo `json:"repos,omitempty" jsonschema:"description=List of git repos to include in the package"`
 
 	// Data pacakges to push into a running cluster
 	DataInjections []ZarfDataInjection `json:"dataInjections,omitempty" jsonschema:"description=Datasets to inject into a pod in the target cluster"`
 }
 
+// ZarfRepo is a git repository to include in the package, written as either a URL or an object with packaging options.
+type ZarfRepo struct {
+	URL      string   `json:"url" jsonschema:"description=The URL of the git repo (optionally ending with @<tag or hash> to only include that ref)"`
+	Depth    int      `json:"depth,omitempty" jsonschema:"description=Not supported yet: shallow repos can't be pushed to the git server so setting a depth fails validation"`
+	Branches []string `json:"branches,omitempty" jsonschema:"description=Only include the branches matching these glob patterns"`
+	Tags     []string `json:"tags,omitempty" jsonschema:"description=Only include the tags matching these glob patterns (without branches the highest semver tag is pushed as the default branch)"`
+	LFS      bool     `json:"lfs,omitempty" jsonschema:"description=Include the Git LFS objects of the included refs and push them to the git server's LFS endpoint"`
+}
+
+// zarfRepoOptions is ZarfRepo without its custom marshaling, used to (un)marshal the object form
+type zarfRepoOptions ZarfRepo
+
+// Scoped returns true if only part of the repo's refs should be included in the package
+func (r ZarfRepo) Scoped() bool {
+	return len(r.Branches) > 0 || len(r.Tags) > 0
+}
+
+// hasOptions returns true if the repo can't be written as a plain URL
+func (r ZarfRepo) hasOptions() bool {
+	return r.Scoped() || r.LFS || r.Depth > 0
+}
+
+// MarshalYAML writes repos without options as a plain URL so older versions of Zarf can read them
+func (r ZarfRepo) MarshalYAML() (interface{}, error) {
+	if !r.hasOptions() {
+		return r.URL, nil
+	}
+	return zarfRepoOptions(r), nil
+}
+
+// UnmarshalYAML reads a repo from either a plain URL or an object with packaging options
+func (r *ZarfRepo) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var url string
+	if err := unmarshal(&url); err == nil {
+		*r = ZarfRepo{URL: url}
+		return nil
+	}
+
+	var options zarfRepoOptions
+	if err := unmarshal(&options); err != nil {
+		return err
+	}
+	*r = ZarfRepo(options)
+	return nil
+}
+
+// MarshalJSON writes repos without options as a plain URL so older versions of Zarf can read them
+func (r ZarfRepo) MarshalJSON() ([]byte, error) {
+	if !r.hasOptions() {
+		return json.Marshal(r.URL)
+	}
+	return json.Marshal(zarfRepoOptions(r))
+}
+
+// UnmarshalJSON reads a repo from either a plain URL or an object with packaging options
+func (r *ZarfRepo) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		*r = ZarfRepo{URL: url}
+		return nil
+	}
+
+	var options zarfRepoOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	*r = ZarfRepo(options)
+	return nil
+}
+
+// JSONSchemaType allows a repo to be written as either a URL or an object in the zarf.yaml schema
+func (ZarfRepo) JSONSchemaType() *jsonschema.Type {
+	reflector := jsonschema.Reflector{DoNotReference: true}
+	options := reflector.Reflect(&zarfRepoOptions{}).Type
+	options.Version = ""
+
+	return &jsonschema.Type{
+		OneOf: []*jsonschema.Type{
+			{Type: "string", Description: "The URL of the git repo, optionally ending with @<tag or hash> to only include that ref"},
+			options,
+		},
+	}
+}
+
 // ZarfComponentOnlyTarget filters a component to only show it for a given OS/Arch
 type ZarfComponentOnlyTarget struct {
 	LocalOS string                   `json:"localOS,omitempty" jsonschema:"description=Only deploy component to specified OS,enum=linux,enum=darwin,enum=windows"`
@@ -76,15 +169,17 @@ type ZarfFile struct {
 
 // ZarfChart defines a helm chart to be deployed.
 type ZarfChart struct {
-	Name        string   `json:"name" jsonschema:"description=The name of the chart to deploy, this should be the name of the chart as it is installed in the helm repo"`
-	ReleaseName string   `json:"releaseName,omitempty" jsonschema:"description=The name of the release to create, defaults to the name of the chart"`
-	Url         string   `json:"url,omitempty" jsonschema:"oneof_required=url,description=The URL of the chart repository or git url if the chart is using a git repo instead of helm repo"`
-	Version     string   `json:"version" jsonschema:"description=The version of the chart to deploy, for git-based charts this is also the tag of the git repo"`
-	Namespace   string   `json:"namespace" jsonschema:"description=The namespace to deploy the chart to"`
-	ValuesFiles []string `json:"valuesFiles,omitempty" jsonschema:"description=List of values files to include in the package, these will be merged together"`
-	GitPath     string   `json:"gitPath,omitempty" jsonschema:"description=If using a git repo, the path to the chart in the repo"`
-	LocalPath   string   `json:"localPath,omitempty" jsonschema:"oneof_required=localPath,description=The path to the chart folder"`
-	NoWait      bool     `json:"noWait,omitempty" jsonschema:"description=Wait for chart resources to be ready before continuing"`
+	Name           string   `json:"name" jsonschema:"description=The name of the chart to deploy, this should be the name of the chart as it is installed in the helm repo"`
+	ReleaseName    string   `json:"releaseName,omitempty" jsonschema:"description=The name of the release to create, defaults to the name of the chart"`
+	Url            string   `json:"url,omitempty" jsonschema:"oneof_required=url,description=The URL of the chart repository, the git url if the chart is using a git repo, or the oci:// reference if the chart is in an OCI registry"`
+	Version        string   `json:"version" jsonschema:"description=The version of the chart to deploy, for git-based charts this is also the tag of the git repo"`
+	Namespace      string   `json:"namespace" jsonschema:"description=The namespace to deploy the chart to"`
+	ValuesFiles    []string `json:"valuesFiles,omitempty" jsonschema:"description=List of values files to include in the package, these will be merged together"`
+	GitPath        string   `json:"gitPath,omitempty" jsonschema:"description=If using a git repo, the path to the chart in the repo"`
+	LocalPath      string   `json:"localPath,omitempty" jsonschema:"oneof_required=localPath,description=The path to the chart folder"`
+	NoWait         bool     `json:"noWait,omitempty" jsonschema:"description=Wait for chart resources to be ready before continuing"`
+	PushToRegistry bool     `json:"pushToRegistry,omitempty" jsonschema:"description=Push the chart to the Zarf registry as an OCI artifact during package deploy so in-cluster tools (such as Flux) can install it"`
+	GoTemplate     bool     `json:"goTemplate,omitempty" jsonschema:"description=Render the values files as Go templates (with sprig functions) during package deploy, using .Variables, .Constants and .Zarf"`
 }
 
 // ZarfManifest defines raw manifests Zarf will deploy as a helm chart
@@ -95,16 +190,88 @@ type ZarfManifest struct {
 	KustomizeAllowAnyDirectory bool     `json:"kustomizeAllowAnyDirectory,omitempty" jsonschema:"description=Allow traversing directory above the current directory if needed for kustomization"`
 	Kustomizations             []string `json:"kustomizations,omitempty" jsonschema:"description=List of kustomization paths to include in the package"`
 	NoWait                     bool     `json:"noWait,omitempty" jsonschema:"description=Wait for manifest resources to be ready before continuing"`
+	GoTemplate                 bool     `json:"goTemplate,omitempty" jsonschema:"description=Render the files as Go templates (with sprig functions) during package deploy, using .Variables, .Constants and .Zarf"`
 }
 
 // ZarfComponentScripts are scripts that run before or after a component is deployed
 type ZarfComponentScripts struct {
-	ShowOutput     bool     `json:"showOutput,omitempty" jsonschema:"description=Show the output of the script during package deployment"`
-	TimeoutSeconds int      `json:"timeoutSeconds,omitempty" jsonschema:"description=Timeout in seconds for the script"`
-	Retry          bool     `json:"retry,omitempty" jsonschema:"description=Retry the script if it fails"`
-	Prepare        []string `json:"prepare,omitempty" jsonschema:"description=Scripts to run before the component is added during package create"`
-	Before         []string `json:"before,omitempty" jsonschema:"description=Scripts to run before the component is deployed"`
-	After          []string `json:"after,omitempty" jsonschema:"description=Scripts to run after the component successfully deploys"`
+	ShowOutput     bool         `json:"showOutput,omitempty" jsonschema:"description=Show the output of the script during package deployment"`
+	TimeoutSeconds int          `json:"timeoutSeconds,omitempty" jsonschema:"description=Timeout in seconds for the script"`
+	Retry          bool         `json:"retry,omitempty" jsonschema:"description=Retry the script if it fails"`
+	Prepare        []ZarfScript `json:"prepare,omitempty" jsonschema:"description=Scripts to run before the component is added during package create"`
+	Before         []ZarfScript `json:"before,omitempty" jsonschema:"description=Scripts to run before the component is deployed"`
+	After          []ZarfScript `json:"after,omitempty" jsonschema:"description=Scripts to run after the component successfully deploys"`
+}
+
+// ZarfScript is a script for a component to run, written as either a command or an object with options.
+type ZarfScript struct {
+	Cmd         string `json:"cmd" jsonschema:"description=The command to run"`
+	SetVariable string `json:"setVariable,omitempty" jsonschema:"description=The name of a variable to set to the trimmed output of the command for the rest of the component and later components,pattern=^[A-Z_]+$"`
+}
+
+// zarfScriptOptions is ZarfScript without its custom marshaling, used to (un)marshal the object form
+type zarfScriptOptions ZarfScript
+
+// MarshalYAML writes scripts without options as a plain command so older versions of Zarf can read them
+func (s ZarfScript) MarshalYAML() (interface{}, error) {
+	if s.SetVariable == "" {
+		return s.Cmd, nil
+	}
+	return zarfScriptOptions(s), nil
+}
+
+// UnmarshalYAML reads a script from either a plain command or an object with options
+func (s *ZarfScript) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var cmd string
+	if err := unmarshal(&cmd); err == nil {
+		*s = ZarfScript{Cmd: cmd}
+		return nil
+	}
+
+	var options zarfScriptOptions
+	if err := unmarshal(&options); err != nil {
+		return err
+	}
+	*s = ZarfScript(options)
+	return nil
+}
+
+// MarshalJSON writes scripts without options as a plain command so older versions of Zarf can read them
+func (s ZarfScript) MarshalJSON() ([]byte, error) {
+	if s.SetVariable == "" {
+		return json.Marshal(s.Cmd)
+	}
+	return json.Marshal(zarfScriptOptions(s))
+}
+
+// UnmarshalJSON reads a script from either a plain command or an object with options
+func (s *ZarfScript) UnmarshalJSON(data []byte) error {
+	var cmd string
+	if err := json.Unmarshal(data, &cmd); err == nil {
+		*s = ZarfScript{Cmd: cmd}
+		return nil
+	}
+
+	var options zarfScriptOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return err
+	}
+	*s = ZarfScript(options)
+	return nil
+}
+
+// JSONSchemaType allows a script to be written as either a command or an object in the zarf.yaml schema
+func (ZarfScript) JSONSchemaType() *jsonschema.Type {
+	reflector := jsonschema.Reflector{DoNotReference: true}
+	options := reflector.Reflect(&zarfScriptOptions{}).Type
+	options.Version = ""
+
+	return &jsonschema.Type{
+		OneOf: []*jsonschema.Type{
+			{Type: "string", Description: "The command to run"},
+			options,
+		},
+	}
 }
 
 // ZarfContainerTarget defines the destination info for a ZarfData target
@@ -127,5 +294,27 @@ type ZarfDataInjection struct {
 type ZarfComponentImport struct {
 	ComponentName string `json:"name,omitempty"`
 	// For further explanation see https://regex101.com/library/Ldx8yG and https://regex101.com/r/Ldx8yG/1
-	Path string `json:"path" jsonschema:"pattern=^(?!.*###ZARF_PKG_VAR_).*$"`
+	Path   string `json:"path,omitempty" jsonschema:"description=The directory of the package to import from (within the url for remote imports),pattern=^(?!.*###ZARF_PKG_VAR_).*$"`
+	URL    string `json:"url,omitempty" jsonschema:"description=A remote package to import from: a git url with a tag or commit (https://host/repo.git@v1.0.0) or an https tarball or an OCI reference (oci://registry/repo:tag),pattern=^(?!.*###ZARF_PKG_VAR_).*$"`
+	Shasum string `json:"shasum,omitempty" jsonschema:"description=The SHA256 checksum of a tarball url"`
+	All    bool   `json:"all,omitempty" jsonschema:"description=Import every component of the package with the name of this component as a prefix"`
+}
+
+// ZarfComponentOverride patches an imported component in a strategic-merge style: charts are matched by name and the other fields replace the imported ones.
+type ZarfComponentOverride struct {
+	Component string                   `json:"component,omitempty" jsonschema:"description=The (original) name of the component to patch when importing a whole package (defaults to every component)"`
+	Charts    []ZarfChartOverride      `json:"charts,omitempty" jsonschema:"description=Patches for the charts of the imported component (matched by name)"`
+	Images    []string                 `json:"images,omitempty" jsonschema:"description=Replaces the images of the imported component"`
+	Scripts   *ZarfComponentScripts    `json:"scripts,omitempty" jsonschema:"description=Replaces the scripts of the imported component"`
+	Only      *ZarfComponentOnlyTarget `json:"only,omitempty" jsonschema:"description=Replaces the only filters of the imported component"`
+}
+
+// ZarfChartOverride patches an imported chart.
+type ZarfChartOverride struct {
+	Name        string   `json:"name" jsonschema:"description=The name of the imported chart to patch"`
+	Patch       string   `json:"$patch,omitempty" jsonschema:"description=How to patch the chart: merge (the default) adds the values files after the imported ones and replace replaces them (delete removes the chart),enum=merge,enum=replace,enum=delete"`
+	Version     string   `json:"version,omitempty" jsonschema:"description=Replaces the version of the chart"`
+	Namespace   string   `json:"namespace,omitempty" jsonschema:"description=Replaces the namespace the chart is deployed to"`
+	ReleaseName string   `json:"releaseName,omitempty" jsonschema:"description=Replaces the name of the release"`
+	ValuesFiles []string `json:"valuesFiles,omitempty" jsonschema:"description=Values files (relative to this package) to add to or replace those of the imported chart"`
 }
@@ -33,6 +33,16 @@ type DeployedPackage struct {
 type DeployedComponent struct {
 	Name            string           `json:"name"`
 	InstalledCharts []InstalledChart `json:"installedCharts"`
+	PushedRepos     []PushedRepo     `json:"pushedRepos,omitempty"`
+	// ValuesOverrides are the helm values set at deploy time for each chart, they are kept when the package is redeployed
+	ValuesOverrides map[string]map[string]any `json:"valuesOverrides,omitempty"`
+}
+
+// PushedRepo records the refs a package pushed to a repo in the git server, so changes made in the git server can be found later.
+type PushedRepo struct {
+	Name string            `json:"name"`
+	URL  string            `json:"url"`
+	Refs map[string]string `json:"refs"`
 }
 
 type InstalledChart struct {
@@ -49,6 +59,11 @@ type GitServerInfo struct {
 
 	Address        string `json:"address" jsonschema:"description=URL address of the git server"`
 	InternalServer bool   `json:"internalServer" jsonschema:"description=Indicates if we are using a git server that Zarf is directly managing"`
+
+	Provider string `json:"provider,omitempty" jsonschema:"description=The type of git server (defaults to gitea),enum=gitea,enum=gitlab,enum=generic"`
+	Group    string `json:"group,omitempty" jsonschema:"description=Group or organization the repos are created under (defaults to the push-user for gitea and gitlab)"`
+
+	RepoNaming string `json:"repoNaming,omitempty" jsonschema:"description=How mirrored repos are named: hash (repo-<crc32 of the URL>) or flat (host-org-repo). Defaults to hash,enum=hash,enum=flat"`
 }
 
 // RegistryInfo contains information Zarf uses to communicate with a container registry to push/pull images.
@@ -32,6 +32,48 @@ type ZarfBuildData struct {
 	Architecture string `json:"architecture"`
 	Timestamp    string `json:"timestamp"`
 	Version      string `json:"version"`
+
+	GitLFS            map[string]ZarfGitLFSData        `json:"gitLFS,omitempty"`
+	ChartDependencies map[string][]ZarfChartDependency `json:"chartDependencies,omitempty"`
+	ComponentImports  map[string]ZarfImportSource      `json:"componentImports,omitempty"`
+}
+
+// ZarfImportSource records what a remote component import url resolved to when the package was created.
+type ZarfImportSource struct {
+	Resolved string `json:"resolved"`
+	Digest   string `json:"digest"`
+}
+
+// ZarfChartDependency records a dependency vendored into a packaged helm chart.
+type ZarfChartDependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository,omitempty"`
+}
+
+// ZarfGitLFSData records the Git LFS objects included in the package for a repo.
+type ZarfGitLFSData struct {
+	Objects int   `json:"objects"`
+	Size    int64 `json:"size"`
+}
+
+// VulnerabilityReport is written during packager.Create() when images are scanned against an offline vulnerability database.
+type VulnerabilityReport struct {
+	Database  string               `json:"database"`
+	Timestamp string               `json:"timestamp"`
+	Threshold string               `json:"threshold,omitempty"`
+	Matches   []VulnerabilityMatch `json:"matches"`
+}
+
+// VulnerabilityMatch is a package in an image that falls within a vulnerable version range.
+type VulnerabilityMatch struct {
+	Image         string   `json:"image"`
+	Package       string   `json:"package"`
+	Version       string   `json:"version"`
+	Type          string   `json:"type"`
+	Vulnerability string   `json:"vulnerability"`
+	Severity      string   `json:"severity"`
+	FixedIn       []string `json:"fixedIn,omitempty"`
 }
 
 // ZarfPackageVariable are variables that can be used to dynamically template K8s resources.
@@ -40,6 +82,34 @@ type ZarfPackageVariable struct {
 	Description string `json:"description,omitempty" jsonschema:"description=A description of the variable to be used when prompting the user a value"`
 	Default     string `json:"default,omitempty" jsonschema:"description=The default value to use for the variable"`
 	Prompt      bool   `json:"prompt,omitempty" jsonschema:"description=Whether to prompt the user for input for this variable"`
+
+	Type      string   `json:"type,omitempty" jsonschema:"description=The type of value the variable accepts (untyped variables accept any value). A file variable is set to the content of the file at the given path,enum=string,enum=int,enum=bool,enum=enum,enum=file,enum=multiline,enum=secret"`
+	Options   []string `json:"options,omitempty" jsonschema:"description=The values an enum variable accepts"`
+	Pattern   string   `json:"pattern,omitempty" jsonschema:"description=A regular expression the value of the variable must match"`
+	Required  bool     `json:"required,omitempty" jsonschema:"description=Whether the variable must be set to a non-empty value"`
+	Sensitive bool     `json:"sensitive,omitempty" jsonschema:"description=Whether the value of the variable is hidden when prompted for and masked in logs and the deployed package record (always true for secret variables)"`
+
+	From ZarfVariableSource `json:"from,omitempty" jsonschema:"description=Where to read the value of the variable from during deploy if it is not --set, the default (or prompt) is used if the value is not found"`
+}
+
+// ZarfVariableSource is where the value of a variable is read from during deploy, only one source can be set.
+type ZarfVariableSource struct {
+	Env       string                `json:"env,omitempty" jsonschema:"description=The name of an environment variable to read the value from"`
+	File      string                `json:"file,omitempty" jsonschema:"description=The path of a local file to read the value from"`
+	Secret    ZarfVariableSourceKey `json:"secret,omitempty" jsonschema:"description=A key of an existing Kubernetes secret to read the value from (the value is always sensitive)"`
+	ConfigMap ZarfVariableSourceKey `json:"configMap,omitempty" jsonschema:"description=A key of an existing Kubernetes configmap to read the value from"`
+}
+
+// ZarfVariableSourceKey is a key in the data of a Kubernetes secret or configmap.
+type ZarfVariableSourceKey struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"description=The namespace of the resource (defaults to default)"`
+	Name      string `json:"name,omitempty" jsonschema:"description=The name of the resource"`
+	Key       string `json:"key,omitempty" jsonschema:"description=The key in the data of the resource"`
+}
+
+// IsSensitive returns true if the value of the variable must not be shown or recorded
+func (v ZarfPackageVariable) IsSensitive() bool {
+	return v.Sensitive || v.Type == "secret"
 }
 
 // ZarfPackageConstant are constants that can be used to dynamically template K8s resources.
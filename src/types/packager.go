@@ -28,4 +28,7 @@ type PackagerConfig struct {
 
 	// Variables set by the user
 	SetVariableMap map[string]string
+
+	// Helm values set by the user, keyed by component/chart
+	ValuesOverridesMap map[string]map[string]any
 }
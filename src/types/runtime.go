@@ -19,6 +19,8 @@ type ZarfDeployOptions struct {
 	Components   string            `json:"components" jsonschema:"description=Comma separated list of optional components to deploy"`
 	SGetKeyPath  string            `json:"sGetKeyPath" jsonschema:"description=Location where the public key component of a cosign key-pair can be found"`
 	SetVariables map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
+	ValuesFiles  []string          `json:"valuesFiles" jsonschema:"description=Helm values files (component/chart=file) to merge on top of the packaged values of a chart"`
+	ResetValues  bool              `json:"resetValues" jsonschema:"description=Discard the helm values overrides recorded by previous deployments of the package"`
 }
 
 // ZarfInitOptions tracks the user-defined options during cluster initialization.
@@ -44,6 +46,9 @@ type ZarfCreateOptions struct {
 	ViewSBOM        bool              `json:"sbom" jsonschema:"description=Whether to pause to allow for viewing the SBOM post-creation"`
 	SBOMOutputDir   string            `json:"sbomOutput" jsonschema:"description=Location to output an SBOM into after package creation"`
 	SetVariables    map[string]string `json:"setVariables" jsonschema:"description=Key-Value map of variable names and their corresponding values that will be used to template against the Zarf package being used"`
+	VulnDB          string            `json:"vulnDB" jsonschema:"description=Path to an offline grype vulnerability database used to scan the package images"`
+	VulnFailOn      string            `json:"vulnFailOn" jsonschema:"description=Fail package creation if a vulnerability at or above this severity is found (negligible, low, medium, high, critical)"`
+	MaxCacheSize    string            `json:"maxCacheSize" jsonschema:"description=Maximum size of the image and git repository cache, least recently used entries are removed after pulling images (e.g. 20Gi)"`
 }
 
 type ConnectString struct {
@@ -69,5 +74,6 @@ type TempPaths struct {
 	Images       string
 	Components   string
 	Sboms        string
+	Vulns        string
 	ZarfYaml     string
 }
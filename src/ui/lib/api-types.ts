@@ -56,11 +56,30 @@ export interface ZarfPackage {
  * Zarf-generated package build data
  */
 export interface ZarfBuildData {
-    architecture: string;
-    terminal:     string;
-    timestamp:    string;
-    user:         string;
-    version:      string;
+    architecture:       string;
+    chartDependencies?: { [key: string]: ZarfChartDependency[] };
+    componentImports?:  { [key: string]: ZarfImportSource };
+    gitLFS?:            { [key: string]: ZarfGitLFSData };
+    terminal:           string;
+    timestamp:          string;
+    user:               string;
+    version:            string;
+}
+
+export interface ZarfChartDependency {
+    name:        string;
+    repository?: string;
+    version:     string;
+}
+
+export interface ZarfImportSource {
+    digest:   string;
+    resolved: string;
+}
+
+export interface ZarfGitLFSData {
+    objects: number;
+    size:    number;
 }
 
 export interface ZarfComponent {
@@ -109,10 +128,14 @@ export interface ZarfComponent {
      * Filter when this component is included in package creation or deployment
      */
     only?: ZarfComponentOnlyTarget;
+    /**
+     * Patches applied in order to the imported component(s)
+     */
+    overrides?: ZarfComponentOverride[];
     /**
      * List of git repos to include in the package
      */
-    repos?: string[];
+    repos?: Array<ZarfRepoClass | string>;
     /**
      * Do not prompt user to install this component
      */
@@ -128,6 +151,10 @@ export interface ZarfChart {
      * If using a git repo
      */
     gitPath?: string;
+    /**
+     * Render the values files as Go templates (with sprig functions) during package deploy
+     */
+    goTemplate?: boolean;
     /**
      * The path to the chart folder
      */
@@ -144,13 +171,17 @@ export interface ZarfChart {
      * Wait for chart resources to be ready before continuing
      */
     noWait?: boolean;
+    /**
+     * Push the chart to the Zarf registry as an OCI artifact during package deploy so
+     * in-cluster tools (such as Flux) can install it
+     */
+    pushToRegistry?: boolean;
     /**
      * The name of the release to create
      */
     releaseName?: string;
     /**
-     * The URL of the chart repository or git url if the chart is using a git repo instead of
-     * helm repo
+     * The URL of the chart repository
      */
     url?: string;
     /**
@@ -228,8 +259,25 @@ export interface ZarfFile {
  * Import a component from another Zarf package
  */
 export interface ZarfComponentImport {
+    /**
+     * Import every component of the package with the name of this component as a prefix
+     */
+    all?:  boolean;
     name?: string;
-    path:  string;
+    /**
+     * The directory of the package to import from (within the url for remote imports)
+     */
+    path?: string;
+    /**
+     * The SHA256 checksum of a tarball url
+     */
+    shasum?: string;
+    /**
+     * A remote package to import from: a git url with a tag or commit
+     * (https://host/repo.git@v1.0.0) or an https tarball or an OCI reference
+     * (oci://registry/repo:tag)
+     */
+    url?: string;
 }
 
 export interface ZarfManifest {
@@ -237,6 +285,10 @@ export interface ZarfManifest {
      * List of individual K8s YAML files to deploy (in order)
      */
     files?: string[];
+    /**
+     * Render the files as Go templates (with sprig functions) during package deploy
+     */
+    goTemplate?: boolean;
     /**
      * List of kustomization paths to include in the package
      */
@@ -304,6 +356,94 @@ export enum LocalOS {
     Windows = "windows",
 }
 
+export interface ZarfComponentOverride {
+    /**
+     * Patches for the charts of the imported component (matched by name)
+     */
+    charts?: ZarfChartOverride[];
+    /**
+     * The (original) name of the component to patch when importing a whole package (defaults
+     * to every component)
+     */
+    component?: string;
+    /**
+     * Replaces the images of the imported component
+     */
+    images?: string[];
+    /**
+     * Replaces the only filters of the imported component
+     */
+    only?: ZarfComponentOnlyTarget;
+    /**
+     * Replaces the scripts of the imported component
+     */
+    scripts?: ZarfComponentScripts;
+}
+
+export interface ZarfChartOverride {
+    /**
+     * How to patch the chart: merge (the default) adds the values files after the imported
+     * ones and replace replaces them (delete removes the chart)
+     */
+    $patch?: Patch;
+    /**
+     * The name of the imported chart to patch
+     */
+    name: string;
+    /**
+     * Replaces the namespace the chart is deployed to
+     */
+    namespace?: string;
+    /**
+     * Replaces the name of the release
+     */
+    releaseName?: string;
+    /**
+     * Values files (relative to this package) to add to or replace those of the imported chart
+     */
+    valuesFiles?: string[];
+    /**
+     * Replaces the version of the chart
+     */
+    version?: string;
+}
+
+/**
+ * How to patch the chart: merge (the default) adds the values files after the imported
+ * ones and replace replaces them (delete removes the chart)
+ */
+export enum Patch {
+    Delete = "delete",
+    Merge = "merge",
+    Replace = "replace",
+}
+
+export interface ZarfRepoClass {
+    /**
+     * Only include the branches matching these glob patterns
+     */
+    branches?: string[];
+    /**
+     * Not supported yet: shallow repos can't be pushed to the git server so setting a depth
+     * fails validation
+     */
+    depth?: number;
+    /**
+     * Include the Git LFS objects of the included refs and push them to the git server's LFS
+     * endpoint
+     */
+    lfs?: boolean;
+    /**
+     * Only include the tags matching these glob patterns (without branches the highest semver
+     * tag is pushed as the default branch)
+     */
+    tags?: string[];
+    /**
+     * The URL of the git repo (optionally ending with @<tag or hash> to only include that ref)
+     */
+    url: string;
+}
+
 /**
  * Custom commands to run before or after package deployment
  */
@@ -311,15 +451,15 @@ export interface ZarfComponentScripts {
     /**
      * Scripts to run after the component successfully deploys
      */
-    after?: string[];
+    after?: Array<ZarfScriptClass | string>;
     /**
      * Scripts to run before the component is deployed
      */
-    before?: string[];
+    before?: Array<ZarfScriptClass | string>;
     /**
      * Scripts to run before the component is added during package create
      */
-    prepare?: string[];
+    prepare?: Array<ZarfScriptClass | string>;
     /**
      * Retry the script if it fails
      */
@@ -334,6 +474,18 @@ export interface ZarfComponentScripts {
     timeoutSeconds?: number;
 }
 
+export interface ZarfScriptClass {
+    /**
+     * The command to run
+     */
+    cmd: string;
+    /**
+     * The name of a variable to set to the trimmed output of the command for the rest of the
+     * component and later components
+     */
+    setVariable?: string;
+}
+
 export interface ZarfPackageConstant {
     /**
      * A description of the constant to explain its purpose on package create or deploy
@@ -401,14 +553,98 @@ export interface ZarfPackageVariable {
      * A description of the variable to be used when prompting the user a value
      */
     description?: string;
+    /**
+     * Where to read the value of the variable from during deploy if it is not --set
+     */
+    from?: ZarfVariableSource;
     /**
      * The name to be used for the variable
      */
     name: string;
+    /**
+     * The values an enum variable accepts
+     */
+    options?: string[];
+    /**
+     * A regular expression the value of the variable must match
+     */
+    pattern?: string;
     /**
      * Whether to prompt the user for input for this variable
      */
     prompt?: boolean;
+    /**
+     * Whether the variable must be set to a non-empty value
+     */
+    required?: boolean;
+    /**
+     * Whether the value of the variable is hidden when prompted for and masked in logs and the
+     * deployed package record (always true for secret variables)
+     */
+    sensitive?: boolean;
+    /**
+     * The type of value the variable accepts (untyped variables accept any value). A file
+     * variable is set to the content of the file at the given path
+     */
+    type?: Type;
+}
+
+/**
+ * Where to read the value of the variable from during deploy if it is not --set
+ */
+export interface ZarfVariableSource {
+    /**
+     * A key of an existing Kubernetes configmap to read the value from
+     */
+    configMap?: ZarfVariableSourceKey;
+    /**
+     * The name of an environment variable to read the value from
+     */
+    env?: string;
+    /**
+     * The path of a local file to read the value from
+     */
+    file?: string;
+    /**
+     * A key of an existing Kubernetes secret to read the value from (the value is always
+     * sensitive)
+     */
+    secret?: ZarfVariableSourceKey;
+}
+
+/**
+ * A key of an existing Kubernetes configmap to read the value from
+ *
+ * A key of an existing Kubernetes secret to read the value from (the value is always
+ * sensitive)
+ */
+export interface ZarfVariableSourceKey {
+    /**
+     * The key in the data of the resource
+     */
+    key?: string;
+    /**
+     * The name of the resource
+     */
+    name?: string;
+    /**
+     * The namespace of the resource (defaults to default)
+     */
+    namespace?: string;
+}
+
+/**
+ * The type of value the variable accepts (untyped variables accept any value). A file
+ * variable is set to the content of the file at the given path
+ */
+export enum Type {
+    Bool = "bool",
+    Enum = "enum",
+    File = "file",
+    Int = "int",
+    Multiline = "multiline",
+    Secret = "secret",
+    String = "string",
 }
 
 export interface ClusterSummary {
@@ -463,10 +699,19 @@ export interface GitServerInfo {
      * URL address of the git server
      */
     address: string;
+    /**
+     * Group or organization the repos are created under (defaults to the push-user for gitea
+     * and gitlab)
+     */
+    group?: string;
     /**
      * Indicates if we are using a git server that Zarf is directly managing
      */
     internalServer: boolean;
+    /**
+     * The type of git server (defaults to gitea)
+     */
+    provider?: Provider;
     /**
      * Password of a user with pull-only access to the git repository. If not provided for an
      * external repository than the push-user is used
@@ -485,6 +730,29 @@ export interface GitServerInfo {
      * Username of a user with push access to the git repository
      */
     pushUsername: string;
+    /**
+     * How mirrored repos are named: hash (repo-<crc32 of the URL>) or flat (host-org-repo).
+     * Defaults to hash
+     */
+    repoNaming?: RepoNaming;
+}
+
+/**
+ * The type of git server (defaults to gitea)
+ */
+export enum Provider {
+    Generic = "generic",
+    Gitea = "gitea",
+    Gitlab = "gitlab",
+}
+
+/**
+ * How mirrored repos are named: hash (repo-<crc32 of the URL>) or flat (host-org-repo).
+ * Defaults to hash
+ */
+export enum RepoNaming {
+    Flat = "flat",
+    Hash = "hash",
 }
 
 /**
@@ -549,8 +817,10 @@ export interface DeployedPackage {
 }
 
 export interface DeployedComponent {
-    installedCharts: InstalledChart[];
-    name:            string;
+    installedCharts:  InstalledChart[];
+    name:             string;
+    pushedRepos?:     PushedRepo[];
+    valuesOverrides?: { [key: string]: { [key: string]: any } };
 }
 
 export interface InstalledChart {
@@ -558,6 +828,12 @@ export interface InstalledChart {
     namespace: string;
 }
 
+export interface PushedRepo {
+    name: string;
+    refs: { [key: string]: string };
+    url:  string;
+}
+
 export interface ZarfCommonOptions {
     /**
      * Path to use to cache images and git repos on package create
@@ -579,6 +855,11 @@ export interface ZarfCreateOptions {
      * Disable the need for shasum validations when pulling down files from the internet
      */
     insecure: boolean;
+    /**
+     * Maximum size of the image and git repository cache, least recently used entries are
+     * removed after pulling images (e.g. 20Gi)
+     */
+    maxCacheSize: string;
     /**
      * Location where the finalized Zarf package will be placed
      */
@@ -600,6 +881,15 @@ export interface ZarfCreateOptions {
      * Disable the generation of SBOM materials during package creation
      */
     skipSBOM: boolean;
+    /**
+     * Path to an offline grype vulnerability database used to scan the package images
+     */
+    vulnDB: string;
+    /**
+     * Fail package creation if a vulnerability at or above this severity is found (negligible,
+     * low, medium, high, critical)
+     */
+    vulnFailOn: string;
 }
 
 export interface ZarfDeployOptions {
@@ -615,6 +905,10 @@ export interface ZarfDeployOptions {
      * Location where a Zarf package to deploy can be found
      */
     packagePath: string;
+    /**
+     * Discard the helm values overrides recorded by previous deployments of the package
+     */
+    resetValues: boolean;
     /**
      * Key-Value map of variable names and their corresponding values that will be used to
      * template against the Zarf package being used
@@ -628,6 +922,10 @@ export interface ZarfDeployOptions {
      * The SHA256 checksum of the package to deploy
      */
     shasum: string;
+    /**
+     * Helm values files (component/chart=file) to merge on top of the packaged values of a chart
+     */
+    valuesFiles: string[];
 }
 
 export interface ZarfInitOptions {
@@ -824,11 +1122,27 @@ const typeMap: any = {
     ], false),
     "ZarfBuildData": o([
         { json: "architecture", js: "architecture", typ: "" },
+        { json: "chartDependencies", js: "chartDependencies", typ: u(undefined, m(a(r("ZarfChartDependency")))) },
+        { json: "componentImports", js: "componentImports", typ: u(undefined, m(r("ZarfImportSource"))) },
+        { json: "gitLFS", js: "gitLFS", typ: u(undefined, m(r("ZarfGitLFSData"))) },
         { json: "terminal", js: "terminal", typ: "" },
         { json: "timestamp", js: "timestamp", typ: "" },
         { json: "user", js: "user", typ: "" },
         { json: "version", js: "version", typ: "" },
     ], false),
+    "ZarfChartDependency": o([
+        { json: "name", js: "name", typ: "" },
+        { json: "repository", js: "repository", typ: u(undefined, "") },
+        { json: "version", js: "version", typ: "" },
+    ], false),
+    "ZarfImportSource": o([
+        { json: "digest", js: "digest", typ: "" },
+        { json: "resolved", js: "resolved", typ: "" },
+    ], false),
+    "ZarfGitLFSData": o([
+        { json: "objects", js: "objects", typ: 0 },
+        { json: "size", js: "size", typ: 0 },
+    ], false),
     "ZarfComponent": o([
         { json: "charts", js: "charts", typ: u(undefined, a(r("ZarfChart"))) },
         { json: "cosignKeyPath", js: "cosignKeyPath", typ: u(undefined, "") },
@@ -842,16 +1156,19 @@ const typeMap: any = {
         { json: "manifests", js: "manifests", typ: u(undefined, a(r("ZarfManifest"))) },
         { json: "name", js: "name", typ: "" },
         { json: "only", js: "only", typ: u(undefined, r("ZarfComponentOnlyTarget")) },
-        { json: "repos", js: "repos", typ: u(undefined, a("")) },
+        { json: "overrides", js: "overrides", typ: u(undefined, a(r("ZarfComponentOverride"))) },
+        { json: "repos", js: "repos", typ: u(undefined, a(u(r("ZarfRepoClass"), ""))) },
         { json: "required", js: "required", typ: u(undefined, true) },
         { json: "scripts", js: "scripts", typ: u(undefined, r("ZarfComponentScripts")) },
     ], false),
     "ZarfChart": o([
         { json: "gitPath", js: "gitPath", typ: u(undefined, "") },
+        { json: "goTemplate", js: "goTemplate", typ: u(undefined, true) },
         { json: "localPath", js: "localPath", typ: u(undefined, "") },
         { json: "name", js: "name", typ: "" },
         { json: "namespace", js: "namespace", typ: "" },
         { json: "noWait", js: "noWait", typ: u(undefined, true) },
+        { json: "pushToRegistry", js: "pushToRegistry", typ: u(undefined, true) },
         { json: "releaseName", js: "releaseName", typ: u(undefined, "") },
         { json: "url", js: "url", typ: u(undefined, "") },
         { json: "valuesFiles", js: "valuesFiles", typ: u(undefined, a("")) },
@@ -876,11 +1193,15 @@ const typeMap: any = {
         { json: "target", js: "target", typ: "" },
     ], false),
     "ZarfComponentImport": o([
+        { json: "all", js: "all", typ: u(undefined, true) },
         { json: "name", js: "name", typ: u(undefined, "") },
-        { json: "path", js: "path", typ: "" },
+        { json: "path", js: "path", typ: u(undefined, "") },
+        { json: "shasum", js: "shasum", typ: u(undefined, "") },
+        { json: "url", js: "url", typ: u(undefined, "") },
     ], false),
     "ZarfManifest": o([
         { json: "files", js: "files", typ: u(undefined, a("")) },
+        { json: "goTemplate", js: "goTemplate", typ: u(undefined, true) },
         { json: "kustomizations", js: "kustomizations", typ: u(undefined, a("")) },
         { json: "kustomizeAllowAnyDirectory", js: "kustomizeAllowAnyDirectory", typ: u(undefined, true) },
         { json: "name", js: "name", typ: "" },
@@ -895,14 +1216,40 @@ const typeMap: any = {
         { json: "architecture", js: "architecture", typ: u(undefined, r("Architecture")) },
         { json: "distros", js: "distros", typ: u(undefined, a("")) },
     ], false),
+    "ZarfComponentOverride": o([
+        { json: "charts", js: "charts", typ: u(undefined, a(r("ZarfChartOverride"))) },
+        { json: "component", js: "component", typ: u(undefined, "") },
+        { json: "images", js: "images", typ: u(undefined, a("")) },
+        { json: "only", js: "only", typ: u(undefined, r("ZarfComponentOnlyTarget")) },
+        { json: "scripts", js: "scripts", typ: u(undefined, r("ZarfComponentScripts")) },
+    ], false),
+    "ZarfChartOverride": o([
+        { json: "$patch", js: "$patch", typ: u(undefined, r("Patch")) },
+        { json: "name", js: "name", typ: "" },
+        { json: "namespace", js: "namespace", typ: u(undefined, "") },
+        { json: "releaseName", js: "releaseName", typ: u(undefined, "") },
+        { json: "valuesFiles", js: "valuesFiles", typ: u(undefined, a("")) },
+        { json: "version", js: "version", typ: u(undefined, "") },
+    ], false),
+    "ZarfRepoClass": o([
+        { json: "branches", js: "branches", typ: u(undefined, a("")) },
+        { json: "depth", js: "depth", typ: u(undefined, 0) },
+        { json: "lfs", js: "lfs", typ: u(undefined, true) },
+        { json: "tags", js: "tags", typ: u(undefined, a("")) },
+        { json: "url", js: "url", typ: "" },
+    ], false),
     "ZarfComponentScripts": o([
-        { json: "after", js: "after", typ: u(undefined, a("")) },
-        { json: "before", js: "before", typ: u(undefined, a("")) },
-        { json: "prepare", js: "prepare", typ: u(undefined, a("")) },
+        { json: "after", js: "after", typ: u(undefined, a(u(r("ZarfScriptClass"), ""))) },
+        { json: "before", js: "before", typ: u(undefined, a(u(r("ZarfScriptClass"), ""))) },
+        { json: "prepare", js: "prepare", typ: u(undefined, a(u(r("ZarfScriptClass"), ""))) },
         { json: "retry", js: "retry", typ: u(undefined, true) },
         { json: "showOutput", js: "showOutput", typ: u(undefined, true) },
         { json: "timeoutSeconds", js: "timeoutSeconds", typ: u(undefined, 0) },
     ], false),
+    "ZarfScriptClass": o([
+        { json: "cmd", js: "cmd", typ: "" },
+        { json: "setVariable", js: "setVariable", typ: u(undefined, "") },
+    ], false),
     "ZarfPackageConstant": o([
         { json: "description", js: "description", typ: u(undefined, "") },
         { json: "name", js: "name", typ: "" },
@@ -920,8 +1267,25 @@ const typeMap: any = {
     "ZarfPackageVariable": o([
         { json: "default", js: "default", typ: u(undefined, "") },
         { json: "description", js: "description", typ: u(undefined, "") },
+        { json: "from", js: "from", typ: u(undefined, r("ZarfVariableSource")) },
         { json: "name", js: "name", typ: "" },
+        { json: "options", js: "options", typ: u(undefined, a("")) },
+        { json: "pattern", js: "pattern", typ: u(undefined, "") },
         { json: "prompt", js: "prompt", typ: u(undefined, true) },
+        { json: "required", js: "required", typ: u(undefined, true) },
+        { json: "sensitive", js: "sensitive", typ: u(undefined, true) },
+        { json: "type", js: "type", typ: u(undefined, r("Type")) },
+    ], false),
+    "ZarfVariableSource": o([
+        { json: "configMap", js: "configMap", typ: u(undefined, r("ZarfVariableSourceKey")) },
+        { json: "env", js: "env", typ: u(undefined, "") },
+        { json: "file", js: "file", typ: u(undefined, "") },
+        { json: "secret", js: "secret", typ: u(undefined, r("ZarfVariableSourceKey")) },
+    ], false),
+    "ZarfVariableSourceKey": o([
+        { json: "key", js: "key", typ: u(undefined, "") },
+        { json: "name", js: "name", typ: u(undefined, "") },
+        { json: "namespace", js: "namespace", typ: u(undefined, "") },
     ], false),
     "ClusterSummary": o([
         { json: "distro", js: "distro", typ: "" },
@@ -946,11 +1310,14 @@ const typeMap: any = {
     ], false),
     "GitServerInfo": o([
         { json: "address", js: "address", typ: "" },
+        { json: "group", js: "group", typ: u(undefined, "") },
         { json: "internalServer", js: "internalServer", typ: true },
+        { json: "provider", js: "provider", typ: u(undefined, r("Provider")) },
         { json: "pullPassword", js: "pullPassword", typ: "" },
         { json: "pullUsername", js: "pullUsername", typ: "" },
         { json: "pushPassword", js: "pushPassword", typ: "" },
         { json: "pushUsername", js: "pushUsername", typ: "" },
+        { json: "repoNaming", js: "repoNaming", typ: u(undefined, r("RepoNaming")) },
     ], false),
     "RegistryInfo": o([
         { json: "address", js: "address", typ: "" },
@@ -975,11 +1342,18 @@ const typeMap: any = {
     "DeployedComponent": o([
         { json: "installedCharts", js: "installedCharts", typ: a(r("InstalledChart")) },
         { json: "name", js: "name", typ: "" },
+        { json: "pushedRepos", js: "pushedRepos", typ: u(undefined, a(r("PushedRepo"))) },
+        { json: "valuesOverrides", js: "valuesOverrides", typ: u(undefined, m(m("any"))) },
     ], false),
     "InstalledChart": o([
         { json: "chartName", js: "chartName", typ: "" },
         { json: "namespace", js: "namespace", typ: "" },
     ], false),
+    "PushedRepo": o([
+        { json: "name", js: "name", typ: "" },
+        { json: "refs", js: "refs", typ: m("") },
+        { json: "url", js: "url", typ: "" },
+    ], false),
     "ZarfCommonOptions": o([
         { json: "cachePath", js: "cachePath", typ: "" },
         { json: "confirm", js: "confirm", typ: true },
@@ -987,19 +1361,24 @@ const typeMap: any = {
     ], false),
     "ZarfCreateOptions": o([
         { json: "insecure", js: "insecure", typ: true },
+        { json: "maxCacheSize", js: "maxCacheSize", typ: "" },
         { json: "outputDirectory", js: "outputDirectory", typ: "" },
         { json: "sbom", js: "sbom", typ: true },
         { json: "sbomOutput", js: "sbomOutput", typ: "" },
         { json: "setVariables", js: "setVariables", typ: m("") },
         { json: "skipSBOM", js: "skipSBOM", typ: true },
+        { json: "vulnDB", js: "vulnDB", typ: "" },
+        { json: "vulnFailOn", js: "vulnFailOn", typ: "" },
     ], false),
     "ZarfDeployOptions": o([
         { json: "components", js: "components", typ: "" },
         { json: "insecure", js: "insecure", typ: true },
         { json: "packagePath", js: "packagePath", typ: "" },
+        { json: "resetValues", js: "resetValues", typ: true },
         { json: "setVariables", js: "setVariables", typ: m("") },
         { json: "sGetKeyPath", js: "sGetKeyPath", typ: "" },
         { json: "shasum", js: "shasum", typ: "" },
+        { json: "valuesFiles", js: "valuesFiles", typ: a("") },
     ], false),
     "ZarfInitOptions": o([
         { json: "applianceMode", js: "applianceMode", typ: true },
@@ -1017,8 +1396,31 @@ const typeMap: any = {
         "linux",
         "windows",
     ],
+    "Patch": [
+        "delete",
+        "merge",
+        "replace",
+    ],
     "Kind": [
         "ZarfInitConfig",
         "ZarfPackageConfig",
     ],
+    "Type": [
+        "bool",
+        "enum",
+        "file",
+        "int",
+        "multiline",
+        "secret",
+        "string",
+    ],
+    "Provider": [
+        "generic",
+        "gitea",
+        "gitlab",
+    ],
+    "RepoNaming": [
+        "flat",
+        "hash",
+    ],
 };